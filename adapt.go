@@ -0,0 +1,142 @@
+package container
+
+
+import (
+	"reflect"
+)
+
+
+// assignable returns the dependency, if it can be assigned to something of the type. Else it
+// returns the dependency adapted into the type (see adapt), if it can be. If method is not ""
+// (empty string), then it always returns the method of the dependency with that name, adapted
+// into the type.
+//
+// If it can be neither, then ok is false.
+func assignable(dependency reflect.Value, typ reflect.Type, method string) (value reflect.Value, ok bool) {
+	if "" == method && dependency.Type().AssignableTo(typ) {
+		return dependency, true
+	}
+
+	return adapt(dependency, typ, method)
+}
+
+
+// adapt tries to turn the dependency into something of the type, when the dependency is not
+// itself assignable to the type. (Or, if method is not "" (empty string), when the method of
+// the dependency with that name is what is wanted.)
+//
+// What can be adapted is:
+//
+// • A channel into a channel of another (possibly directional) channel type, with the same
+// element type. For example, a chan Event into a <-chan Event (even when both are named types).
+//
+// • A func into a func of another type, so long as each of the parameters of the type can be
+// passed to the func, and each of the results of the func can be returned as the results of
+// the type.
+//
+// • A method of the dependency into a func (in the same way). But only if the method is given
+// (as in `inject:"name,method=Handle"`). A method is never picked just because it happens to fit.
+//
+// If the dependency cannot be adapted, then ok is false.
+func adapt(dependency reflect.Value, typ reflect.Type, method string) (adapted reflect.Value, ok bool) {
+	if !dependency.IsValid() {
+		return reflect.Value{}, false
+	}
+
+	if "" != method {
+		if reflect.Func != typ.Kind() {
+			return reflect.Value{}, false
+		}
+
+		fn := dependency.MethodByName(method)
+		if !fn.IsValid() {
+			return reflect.Value{}, false
+		}
+
+		return adaptFunc(fn, typ)
+	}
+
+	switch typ.Kind() {
+	case reflect.Chan:
+		return adaptChan(dependency, typ)
+	case reflect.Func:
+		if reflect.Func != dependency.Kind() {
+			return reflect.Value{}, false
+		}
+
+		return adaptFunc(dependency, typ)
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+
+// adaptChan turns a channel into a channel of the type, if they have the same element type
+// and the channel can be used in the direction(s) of the type.
+func adaptChan(ch reflect.Value, typ reflect.Type) (reflect.Value, bool) {
+	chType := ch.Type()
+
+	if reflect.Chan != chType.Kind() || chType.Elem() != typ.Elem() {
+		return reflect.Value{}, false
+	}
+	if reflect.BothDir != chType.ChanDir() && chType.ChanDir() != typ.ChanDir() {
+		return reflect.Value{}, false
+	}
+
+	// Go from the (possibly named) channel type, to the unnamed channel type with the
+	// same direction(s) as the type, to the type.
+	unnamed := reflect.New(reflect.ChanOf(typ.ChanDir(), typ.Elem())).Elem()
+	unnamed.Set(ch.Convert(reflect.ChanOf(chType.ChanDir(), chType.Elem())))
+
+	return unnamed.Convert(typ), true
+}
+
+
+// adaptFunc turns a func into a func of the type, if each of the parameters of the type can be
+// passed to the func, and each of the results of the func can be returned as the results of the type.
+func adaptFunc(fn reflect.Value, typ reflect.Type) (reflect.Value, bool) {
+	fnType := fn.Type()
+
+	if fnType.ConvertibleTo(typ) {
+		return fn.Convert(typ), true
+	}
+
+	if fnType.NumIn() != typ.NumIn() || fnType.NumOut() != typ.NumOut() || fnType.IsVariadic() != typ.IsVariadic() {
+		return reflect.Value{}, false
+	}
+	for i:=0; i<typ.NumIn(); i++ {
+		if !typ.In(i).AssignableTo(fnType.In(i)) {
+			return reflect.Value{}, false
+		}
+	}
+	for i:=0; i<typ.NumOut(); i++ {
+		if !fnType.Out(i).AssignableTo(typ.Out(i)) {
+			return reflect.Value{}, false
+		}
+	}
+
+	adapted := reflect.MakeFunc(typ, func(args []reflect.Value) []reflect.Value {
+		for i, arg := range args {
+			converted := reflect.New(fnType.In(i)).Elem()
+			converted.Set(arg)
+			args[i] = converted
+		}
+
+		var results []reflect.Value
+		if fnType.IsVariadic() {
+			results = fn.CallSlice(args)
+		} else {
+			results = fn.Call(args)
+		}
+
+		for i, result := range results {
+			converted := reflect.New(typ.Out(i)).Elem()
+			converted.Set(result)
+			results[i] = converted
+		}
+
+		return results
+	})
+
+	return adapted, true
+}
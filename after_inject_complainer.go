@@ -0,0 +1,87 @@
+package container
+
+
+import (
+	"fmt"
+)
+
+
+// AfterInjectComplainer is an 'error' that represents the situation where the AfterInject
+// method (see AfterInjecter) of something that was injected into returned an error.
+//
+// The Path method says where that something is, relative to what was passed to the
+// Inject method. For example:
+//
+//	["eu-west"][3].Dependencies()
+//
+// The error the AfterInject method returned is returned by the Err method. It is also
+// returned by the Unwrap method, so errors.Is and errors.As see it.
+//
+// errors.Is(err, ErrAfterInject) reports true for an AfterInjectComplainer.
+type AfterInjectComplainer interface {
+	error
+	AfterInjectComplainer()
+	Path() string
+	Err() error
+}
+
+
+// internalAfterInjectComplainer is the only underlying implementation that fits the
+// AfterInjectComplainer interface, in this library.
+type internalAfterInjectComplainer struct {
+	typeName string
+	path string
+	err error
+}
+
+
+// newAfterInjectComplainer creates a new internalAfterInjectComplainer (struct) and
+// returns it as an AfterInjectComplainer (interface).
+func newAfterInjectComplainer(typeName string, path string, err error) AfterInjectComplainer {
+	complainer := internalAfterInjectComplainer{
+		typeName:typeName,
+		path:path,
+		err:err,
+	}
+
+	return &complainer
+}
+
+
+func (complainer *internalAfterInjectComplainer) Error() string {
+	var at string
+	if "" != complainer.path {
+		at = " at " + complainer.path
+	}
+
+	return fmt.Sprintf("Problem after injecting dependencies into %s%s: %v", complainer.typeName, at, complainer.err)
+}
+
+
+func (complainer *internalAfterInjectComplainer) AfterInjectComplainer() {
+	// Nothing here.
+}
+
+
+// Path method is necessary to satisfy the 'AfterInjectComplainer' interface.
+func (complainer *internalAfterInjectComplainer) Path() string {
+	return complainer.path
+}
+
+
+// Err method is necessary to satisfy the 'AfterInjectComplainer' interface.
+func (complainer *internalAfterInjectComplainer) Err() error {
+	return complainer.err
+}
+
+
+// Unwrap returns the same thing as the Err method.
+func (complainer *internalAfterInjectComplainer) Unwrap() error {
+	return complainer.err
+}
+
+
+// Is makes it so errors.Is(err, ErrAfterInject) works.
+func (complainer *internalAfterInjectComplainer) Is(target error) bool {
+	return ErrAfterInject == target
+}
@@ -0,0 +1,61 @@
+package container
+
+
+// Alias makes it so another name can be used for something that is already registered.
+//
+// For example, after:
+//
+//	err := Container.Alias("db", "primary-db")
+//
+// both `inject:"db"` and `inject:"primary-db"` get the very same dependency. (And the
+// same goes for Get, Decorate, and so on.)
+//
+// The target can itself be an alias. But it must already be registered (or be an alias
+// already). Else a DependenciesNotFoundComplainer is returned. And the alias must not
+// already be in use (as a name or as an alias). Else an AlreadyRegisteredComplainer is
+// returned.
+//
+// Because of these two rules, an alias can never be dangling, and can never (directly
+// or indirectly) refer to itself.
+func (container *internalContainer) Alias(alias string, target string) error {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] Alias(%q, %q)", alias, target)
+
+	if err := container.alias(alias, target); nil != err {
+		logger.Printf("[END]   Alias(%q, %q) with ERROR: %q", alias, target, err)
+		return err
+	}
+
+	logger.Printf("[END]   Alias(%q, %q)", alias, target)
+
+	return nil
+}
+
+func (container *internalContainer) alias(alias string, target string) error {
+	alias  = container.qualify(alias)
+	target = container.qualify(target)
+
+	if _, ok := container.lookup(target); !ok {
+		return newDependenciesNotFoundComplainer(target)
+	}
+
+	container.mutex.Lock()
+	defer container.mutex.Unlock()
+
+	if nil != container.sealed.Load() {
+		return newSealedComplainer(alias)
+	}
+
+	if _,ok := container.registry[alias]; ok {
+		return newAlreadyRegisteredComplainer(alias)
+	}
+	if _,ok := container.aliases[alias]; ok {
+		return newAlreadyRegisteredComplainer(alias)
+	}
+
+	container.aliases[alias] = target
+
+	return nil
+}
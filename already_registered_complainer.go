@@ -6,6 +6,18 @@ import (
 )
 
 
+// AlreadyRegisteredComplainer is an 'error' that represents the situation where
+// something tries to register a dependency with the 'dependency injection container'
+// using a name that is already in use.
+//
+// errors.Is(err, ErrAlreadyRegistered) reports true for an AlreadyRegisteredComplainer.
+type AlreadyRegisteredComplainer interface {
+	error
+	AlreadyRegisteredComplainer()
+	DependencyName() string
+}
+
+
 type internalAlreadyRegisteredComplainer struct {
 	name string
 }
@@ -23,3 +35,20 @@ func newAlreadyRegisteredComplainer(name string) error {
 func (err *internalAlreadyRegisteredComplainer) Error() string {
 	return fmt.Sprintf("Dependency %q is already registered.", err.name)
 }
+
+
+func (err *internalAlreadyRegisteredComplainer) AlreadyRegisteredComplainer() {
+	// Nothing here.
+}
+
+
+// DependencyName method is necessary to satisfy the 'AlreadyRegisteredComplainer' interface.
+func (err *internalAlreadyRegisteredComplainer) DependencyName() string {
+	return err.name
+}
+
+
+// Is makes it so errors.Is(err, ErrAlreadyRegistered) works.
+func (err *internalAlreadyRegisteredComplainer) Is(target error) bool {
+	return ErrAlreadyRegistered == target
+}
@@ -0,0 +1,113 @@
+package container
+
+
+import (
+	"fmt"
+	"strings"
+)
+
+
+// AmbiguousDependencyComplainer is an 'error' that represents the situation where the
+// 'dependency injection container' tries to find a dependency by its type (see the
+// Invoke and Construct methods), but more than one registered dependency is of that type.
+//
+// The Candidates method returns the names of the dependencies that are of that type.
+// To pick one of them, pass its name to Invoke (or Construct) for that parameter.
+//
+// It is also returned when no dependency is known to be of that type, but there are
+// dependencies whose types are not known until they are provided (see RegisterProvider,
+// RegisterScoped, and RegisterTransient), any of which might be of that type. Then the
+// Candidates method returns the names of those.
+//
+// errors.Is(err, ErrAmbiguousDependency) reports true for an AmbiguousDependencyComplainer.
+type AmbiguousDependencyComplainer interface {
+	error
+	AmbiguousDependencyComplainer()
+	DependencyType() string
+	RequiredBy() string
+	Candidates() []string
+}
+
+
+// internalAmbiguousDependencyComplainer is the only underlying implementation that fits the
+// AmbiguousDependencyComplainer interface, in this library.
+type internalAmbiguousDependencyComplainer struct {
+	dependencyType string
+	requiredBy string
+	candidates []string
+
+	// unknown is whether the candidates are dependencies whose types are not known
+	// (rather than dependencies that are of the type).
+	unknown bool
+}
+
+
+// newAmbiguousDependencyComplainer creates a new internalAmbiguousDependencyComplainer (struct) and
+// returns it as an AmbiguousDependencyComplainer (interface).
+func newAmbiguousDependencyComplainer(dependencyType string, requiredBy string, candidates []string) AmbiguousDependencyComplainer {
+	complainer := internalAmbiguousDependencyComplainer{
+		dependencyType:dependencyType,
+		requiredBy:requiredBy,
+		candidates:append([]string(nil), candidates...),
+	}
+
+	return &complainer
+}
+
+
+// newUnknownTypeComplainer creates a new internalAmbiguousDependencyComplainer (struct) for
+// when none of the dependencies are known to be of the type, but the types of the candidates
+// are not known, and returns it as an AmbiguousDependencyComplainer (interface).
+func newUnknownTypeComplainer(dependencyType string, requiredBy string, candidates []string) AmbiguousDependencyComplainer {
+	complainer := internalAmbiguousDependencyComplainer{
+		dependencyType:dependencyType,
+		requiredBy:requiredBy,
+		candidates:append([]string(nil), candidates...),
+		unknown:true,
+	}
+
+	return &complainer
+}
+
+
+func (complainer *internalAmbiguousDependencyComplainer) Error() string {
+	quoted := make([]string, len(complainer.candidates))
+	for i, candidate := range complainer.candidates {
+		quoted[i] = fmt.Sprintf("%q", candidate)
+	}
+
+	if complainer.unknown {
+		return fmt.Sprintf("No dependency is known to be of type %s (required by %s), but the types of these dependencies are not known until they are provided: %s. Pass the name of the one to use.", complainer.dependencyType, complainer.requiredBy, strings.Join(quoted, ", "))
+	}
+
+	return fmt.Sprintf("More than one dependency is of type %s (required by %s): %s. Pass the name of the one to use.", complainer.dependencyType, complainer.requiredBy, strings.Join(quoted, ", "))
+}
+
+
+func (complainer *internalAmbiguousDependencyComplainer) AmbiguousDependencyComplainer() {
+	// Nothing here.
+}
+
+
+// DependencyType method is necessary to satisfy the 'AmbiguousDependencyComplainer' interface.
+func (complainer *internalAmbiguousDependencyComplainer) DependencyType() string {
+	return complainer.dependencyType
+}
+
+
+// RequiredBy method is necessary to satisfy the 'AmbiguousDependencyComplainer' interface.
+func (complainer *internalAmbiguousDependencyComplainer) RequiredBy() string {
+	return complainer.requiredBy
+}
+
+
+// Candidates method is necessary to satisfy the 'AmbiguousDependencyComplainer' interface.
+func (complainer *internalAmbiguousDependencyComplainer) Candidates() []string {
+	return append([]string(nil), complainer.candidates...)
+}
+
+
+// Is makes it so errors.Is(err, ErrAmbiguousDependency) works.
+func (complainer *internalAmbiguousDependencyComplainer) Is(target error) bool {
+	return ErrAmbiguousDependency == target
+}
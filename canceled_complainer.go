@@ -0,0 +1,81 @@
+package container
+
+
+import (
+	"fmt"
+)
+
+
+// CanceledComplainer is an 'error' that represents the situation where the
+// context.Context passed to GetContext or InjectContext was canceled (or its
+// deadline was exceeded) before a dependency could be resolved.
+//
+// The Err method returns what the context.Context's Err method returned (i.e.,
+// context.Canceled or context.DeadlineExceeded). It is also returned by the
+// Unwrap method, so code such as the following works:
+//
+//	if errors.Is(err, context.DeadlineExceeded) {
+//		//@TODO
+//	}
+//
+// errors.Is(err, ErrCanceled) reports true for a CanceledComplainer.
+type CanceledComplainer interface {
+	error
+	CanceledComplainer()
+	DependencyName() string
+	Err() error
+}
+
+
+// internalCanceledComplainer is the only underlying implementation that fits the
+// CanceledComplainer interface, in this library.
+type internalCanceledComplainer struct {
+	dependencyName string
+	err error
+}
+
+
+// newCanceledComplainer creates a new internalCanceledComplainer (struct) and
+// returns it as a CanceledComplainer (interface).
+func newCanceledComplainer(dependencyName string, err error) CanceledComplainer {
+	complainer := internalCanceledComplainer{
+		dependencyName:dependencyName,
+		err:err,
+	}
+
+	return &complainer
+}
+
+
+func (complainer *internalCanceledComplainer) Error() string {
+	return fmt.Sprintf("Gave up resolving dependency %q: %v", complainer.dependencyName, complainer.err)
+}
+
+
+func (complainer *internalCanceledComplainer) CanceledComplainer() {
+	// Nothing here.
+}
+
+
+// DependencyName method is necessary to satisfy the 'CanceledComplainer' interface.
+func (complainer *internalCanceledComplainer) DependencyName() string {
+	return complainer.dependencyName
+}
+
+
+// Err method is necessary to satisfy the 'CanceledComplainer' interface.
+func (complainer *internalCanceledComplainer) Err() error {
+	return complainer.err
+}
+
+
+// Unwrap returns the same thing as the Err method.
+func (complainer *internalCanceledComplainer) Unwrap() error {
+	return complainer.err
+}
+
+
+// Is makes it so errors.Is(err, ErrCanceled) works.
+func (complainer *internalCanceledComplainer) Is(target error) bool {
+	return ErrCanceled == target
+}
@@ -0,0 +1,135 @@
+package container
+
+
+import (
+	"fmt"
+	"reflect"
+)
+
+
+// Check looks through the given types for struct fields that have an `inject`
+// struct tag, but that the 'dependency injection container' would not be able
+// to set. (For example, because the struct field is unexported.)
+//
+// Check is meant to be used like a 'lint' check, from a test. For example:
+//
+//	func TestInjectTags(t *testing.T) {
+//		if err := container.Check(
+//			(*billing.Service)(nil),
+//			(*audit.Writer)(nil),
+//		); nil != err {
+//			t.Error(err)
+//		}
+//	}
+//
+// Each of the things passed to Check can be a struct, a pointer to a struct,
+// or a reflect.Type of one of those. (Nil pointers are fine. Only the type
+// matters.)
+//
+// If the type (or rather, a pointer to the type) fits a Depender, then what
+// its Dependencies method returns is checked too.
+//
+// If only one problem is found, then it is returned as an UnsettableFieldComplainer.
+// If more than one problem is found, then an InjectionComplainer is returned.
+func Check(things ...interface{}) error {
+
+	// Initialize.
+	problems := newInjectionComplainer().(*internalInjectionComplainer)
+
+	for _, thing := range things {
+		typ, ok := thing.(reflect.Type)
+		if !ok {
+			typ = reflect.TypeOf(thing)
+		}
+		if nil == typ {
+			continue
+		}
+
+		// A struct would be injected by passing a pointer to it.
+		if reflect.Struct == typ.Kind() {
+			typ = reflect.PtrTo(typ)
+		}
+
+		if reflect.Ptr != typ.Kind() || reflect.Struct != typ.Elem().Kind() {
+			continue
+		}
+
+		// Only the type matters. So create a new one, so we never call
+		// the Dependencies method on a nil pointer.
+		checkValue(reflect.New(typ.Elem()).Interface(), "", problems)
+	}
+
+	// If we had any problems, then return an error.
+	return problems.err()
+}
+
+
+// check is used by the Inject method, of a container created with the Strict option,
+// to make sure that everything can be set, before anything is set.
+func (container *internalContainer) check(thing interface{}, path string) error {
+
+	// Initialize.
+	problems := newInjectionComplainer().(*internalInjectionComplainer)
+
+	checkValue(thing, path, problems)
+
+	// If we had any problems, then return an error.
+	return problems.err()
+}
+
+
+// checkValue goes through the 'thing' the same way the Inject method would, and
+// adds an UnsettableFieldComplainer to the problems, for each struct field that
+// has an `inject` struct tag that could not be set.
+func checkValue(thing interface{}, path string, problems *internalInjectionComplainer) {
+
+	if depender, ok := thing.(Depender); ok {
+		if otherThing := depender.Dependencies(); nil != otherThing {
+			checkValue(otherThing, path+".Dependencies()", problems)
+		}
+	}
+
+	value := reflect.ValueOf(thing)
+
+	switch value.Kind() {
+		case reflect.Array, reflect.Slice:
+			length := value.Len()
+			for i:=0; i<length; i++ {
+				checkValue(value.Index(i).Interface(), fmt.Sprintf("%s[%d]", path, i), problems)
+			}
+
+		case reflect.Map:
+			for _, key := range sortedMapKeys(value) {
+				checkValue(value.MapIndex(key).Interface(), fmt.Sprintf("%s[%#v]", path, key.Interface()), problems)
+			}
+
+		case reflect.Ptr:
+			if reflect.Struct == value.Type().Elem().Kind() {
+				checkStructType(value.Type().Elem(), value.Type().String(), path, true, problems)
+			}
+
+		case reflect.Struct:
+			checkStructType(value.Type(), value.Type().String(), path, false, problems)
+
+		default:
+			// Nothing here.
+	}
+}
+
+
+// checkStructType adds an UnsettableFieldComplainer to the problems, for each struct field
+// that has an `inject` struct tag that could not be set.
+//
+// The typeName is how the struct type is referred to in the complainers. (I.e., with a "*"
+// in front of it when it is accessed through a pointer.)
+func checkStructType(typ reflect.Type, typeName string, path string, addressable bool, problems *internalInjectionComplainer) {
+
+	for _, field := range planFor(typ).fields {
+		switch {
+		case !addressable:
+			problems.insert( newUnsettableFieldComplainer(field.dependencyName, typeName+"."+field.name, path+"."+field.name, false) )
+		case !field.exported:
+			problems.insert( newUnsettableFieldComplainer(field.dependencyName, typeName+"."+field.name, path+"."+field.name, true) )
+		}
+	}
+}
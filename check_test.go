@@ -0,0 +1,149 @@
+package container
+
+
+import (
+	"testing"
+
+	"errors"
+	"reflect"
+)
+
+
+type okDependencies_TestCheck struct {
+	Logger interface{} `inject:"logger"`
+}
+
+type ok_TestCheck struct {
+	dependencies okDependencies_TestCheck
+	Name string
+}
+
+func (thing *ok_TestCheck) Dependencies() interface{} {
+	return &thing.dependencies
+}
+
+
+type unexported_TestCheck struct {
+	logger interface{} `inject:"logger"`
+}
+
+
+type nonPointerDependencies_TestCheck struct {
+	dependencies okDependencies_TestCheck
+}
+
+func (thing *nonPointerDependencies_TestCheck) Dependencies() interface{} {
+	return thing.dependencies
+}
+
+
+func TestCheck(t *testing.T) {
+
+	tests := []struct{
+		Things        []interface{}
+		ExpectedCount int
+		ExpectedField string
+	}{
+		{
+			Things:        []interface{}{},
+			ExpectedCount: 0,
+		},
+		{
+			Things:        []interface{}{(*ok_TestCheck)(nil)},
+			ExpectedCount: 0,
+		},
+		{
+			Things:        []interface{}{ok_TestCheck{}},
+			ExpectedCount: 0,
+		},
+		{
+			Things:        []interface{}{(*unexported_TestCheck)(nil)},
+			ExpectedCount: 1,
+			ExpectedField: "*container.unexported_TestCheck.logger",
+		},
+		{
+			Things:        []interface{}{reflect.TypeOf(unexported_TestCheck{})},
+			ExpectedCount: 1,
+			ExpectedField: "*container.unexported_TestCheck.logger",
+		},
+		{
+			Things:        []interface{}{(*nonPointerDependencies_TestCheck)(nil)},
+			ExpectedCount: 1,
+			ExpectedField: "container.okDependencies_TestCheck.Logger",
+		},
+		{
+			Things:        []interface{}{(*ok_TestCheck)(nil), (*unexported_TestCheck)(nil), (*nonPointerDependencies_TestCheck)(nil)},
+			ExpectedCount: 2,
+		},
+	}
+
+
+	for testNumber, test := range tests {
+
+		err := Check(test.Things...)
+
+		switch test.ExpectedCount {
+		case 0:
+			if nil != err {
+				t.Errorf("For test #%d, did not expect an error, but actually got one: %q", testNumber, err)
+				continue
+			}
+		case 1:
+			complainer, ok := err.(UnsettableFieldComplainer)
+			if !ok {
+				t.Errorf("For test #%d, expected an UnsettableFieldComplainer, but actually got: (%T) %q", testNumber, err, err)
+				continue
+			}
+			if expected, actual := test.ExpectedField, complainer.Field(); expected != actual {
+				t.Errorf("For test #%d, expected field %q, but actually got %q.", testNumber, expected, actual)
+				continue
+			}
+			if !errors.Is(err, ErrUnsettableField) {
+				t.Errorf("For test #%d, expected errors.Is(err, ErrUnsettableField) to be true, but it wasn't.", testNumber)
+				continue
+			}
+		default:
+			complainer, ok := err.(InjectionComplainer)
+			if !ok {
+				t.Errorf("For test #%d, expected an InjectionComplainer, but actually got: (%T) %q", testNumber, err, err)
+				continue
+			}
+			if expected, actual := test.ExpectedCount, len(complainer.Errs()); expected != actual {
+				t.Errorf("For test #%d, expected %d errors, but actually got %d: %q", testNumber, expected, actual, err)
+				continue
+			}
+		}
+	}
+}
+
+
+func TestInjectStrict(t *testing.T) {
+
+	type Thing struct {
+		Exported   string `inject:"apple"`
+		unexported string `inject:"apple"`
+	}
+
+	container := New(Strict())
+	container.Register("apple", "one")
+
+	thing := new(Thing)
+
+	err := container.Inject(thing)
+
+	complainer, ok := err.(UnsettableFieldComplainer)
+	if !ok {
+		t.Errorf("Expected an UnsettableFieldComplainer, but actually got: (%T) %q", err, err)
+		return
+	}
+
+	if expected, actual := ".unexported", complainer.Path(); expected != actual {
+		t.Errorf("Expected path %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	if expected, actual := "", thing.Exported; expected != actual {
+		t.Errorf("Expected nothing to have been injected, but thing.Exported was %q.", actual)
+		return
+	}
+}
@@ -0,0 +1,61 @@
+package container
+
+
+import (
+	"fmt"
+)
+
+
+// ClosedComplainer is an 'error' that represents the situation where something tries to
+// resolve a dependency through a 'dependency injection container' (or scope) that has been
+// closed (see the Close method). Or through one whose ancestor has been closed.
+//
+// This is what a Lazy[T] or Provider[T] injected from a scope returns, if it is used after
+// the scope was closed. (Rather than constructing something that nothing would ever close.)
+//
+// errors.Is(err, ErrClosed) reports true for a ClosedComplainer.
+type ClosedComplainer interface {
+	error
+	ClosedComplainer()
+	DependencyName() string
+}
+
+
+// internalClosedComplainer is the only underlying implementation that fits the
+// ClosedComplainer interface, in this library.
+type internalClosedComplainer struct {
+	dependencyName string
+}
+
+
+// newClosedComplainer creates a new internalClosedComplainer (struct) and
+// returns it as a ClosedComplainer (interface).
+func newClosedComplainer(dependencyName string) ClosedComplainer {
+	complainer := internalClosedComplainer{
+		dependencyName:dependencyName,
+	}
+
+	return &complainer
+}
+
+
+func (complainer *internalClosedComplainer) Error() string {
+	return fmt.Sprintf("Dependency %q cannot be resolved, because the container has been closed.", complainer.dependencyName)
+}
+
+
+func (complainer *internalClosedComplainer) ClosedComplainer() {
+	// Nothing here.
+}
+
+
+// DependencyName method is necessary to satisfy the 'ClosedComplainer' interface.
+func (complainer *internalClosedComplainer) DependencyName() string {
+	return complainer.dependencyName
+}
+
+
+// Is makes it so errors.Is(err, ErrClosed) works.
+func (complainer *internalClosedComplainer) Is(target error) bool {
+	return ErrClosed == target
+}
@@ -0,0 +1,84 @@
+/*
+Command containergen generates Go code that injects dependencies without reflection.
+
+It scans the Go files of a package for structs with `inject` struct tags (and for
+types with a Dependencies method, see container.Depender), and writes out a func for
+each of them that does the same wiring that container.Container's InjectContext
+method does, except using plain Go code rather than reflection.
+
+It is meant to be used with go generate, as in:
+
+	//go:generate containergen
+
+For example, for:
+
+	type Service struct {
+		Logger *log.Logger `inject:"logger"`
+	}
+
+it generates:
+
+	// injectService injects the dependencies of a *Service. It does what
+	// container.Container's InjectContext method does, but without reflection.
+	func injectService(ctx context.Context, c container.Container, thing *Service) error {
+		//@TODO
+	}
+
+If what a Dependencies method returns cannot be figured out, then that type is skipped
+(and a warning is written out). containergen can figure it out when the Dependencies
+method returns a pointer to a field of the receiver, as in:
+
+	func (s *Service) Dependencies() interface{} {
+		return &s.dependencies
+	}
+
+Structs with an `inject` struct tag that begins with "group:" or "prefix:", that is
+"@container", or that has options (such as "method="), and structs with a container.Lazy
+or container.Provider field, are skipped too (and a warning is written out). Those need to
+be injected with the InjectContext method.
+
+The generated funcs do not adapt funcs, methods, or channels (the way the InjectContext
+method does). A func or chan field is only injected into if the dependency is of its type.
+
+If the type has an AfterInject method (see container.AfterInjecter), then the generated
+func calls it, once everything has been injected.
+
+Unlike the InjectContext method, the generated funcs return the first problem they run
+into. If a dependency is of the wrong type, then the error returned is one for which
+errors.Is(err, container.ErrWrongType) reports true. And if an AfterInject method returns
+an error, then the error returned is one for which errors.Is(err, container.ErrAfterInject)
+reports true.
+
+Before writing anything out, containergen makes sure that each name in an `inject`
+struct tag is registered. It does this by looking for calls of the form:
+
+	Container.Register("name", ...)
+	Container.RegisterProvider("name", ...)
+	Container.RegisterScoped("name", ...)
+	Container.RegisterTransient("name", ...)
+	Container.Alias("name", ...)
+	container.NewModule("module", []string{"name", ...}, ...)
+
+in the Go files of the directories given with the -registrations flag (which defaults to
+the package's own directory). Names that are registered some other way can be given with
+the -names flag. If any names are missing, then containergen fails, listing them. (This check
+can be turned off with -check=false.)
+
+Usage:
+
+	containergen [flags]
+
+The flags are:
+
+	-dir string
+		the directory of the package to generate code for (default ".")
+	-output string
+		the file to write the generated code to (default "containergen_gen.go")
+	-registrations string
+		comma-separated directories to look for registrations in (default: the -dir directory)
+	-names string
+		comma-separated names that are registered in some other way
+	-check
+		whether to fail when a name is not registered (default true)
+*/
+package main
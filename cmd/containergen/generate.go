@@ -0,0 +1,780 @@
+package main
+
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+
+const containerImportPath = "github.com/reiver/go-container"
+
+
+// config is what the flags (see main) say to do.
+type config struct {
+	dir string
+	output string
+	registrations []string
+	names []string
+	check bool
+}
+
+
+// injectable is a type that gets a generated inject func.
+type injectable struct {
+	typeName string
+	fields []injectField
+
+	// dependencies is the name of the (struct) type that the Dependencies method returns
+	// a pointer to, or "" (empty string) if the type does not have a Dependencies method.
+	dependencies string
+
+	// afterInject is whether the type has an AfterInject method (see container.AfterInjecter).
+	afterInject bool
+}
+
+
+// injectField is a struct field with an `inject` struct tag.
+type injectField struct {
+	name string
+	dependencyName string
+	typeExpr string
+	anyType bool
+	requiredBy string
+
+	// imports are the imports that the typeExpr uses, keyed by the name they are referred to by.
+	imports map[string]string
+}
+
+
+// generator holds what has been found out about the package so far.
+type generator struct {
+	fset *token.FileSet
+	packageName string
+	structs map[string]*ast.StructType
+	injectables map[string]*injectable
+	order []string
+	unsupported map[string]struct{}
+	problems []string
+	warnings []string
+}
+
+
+// generate returns the generated code for the package in the directory, along with any warnings.
+func generate(cfg config) (code []byte, warnings []string, err error) {
+	gen := generator{
+		fset:token.NewFileSet(),
+		structs:map[string]*ast.StructType{},
+		injectables:map[string]*injectable{},
+		unsupported:map[string]struct{}{},
+	}
+
+	files, err := parseDir(gen.fset, cfg.dir, cfg.output)
+	if nil != err {
+		return nil, nil, err
+	}
+	if 0 >= len(files) {
+		return nil, nil, fmt.Errorf("no Go files in %s", cfg.dir)
+	}
+
+	gen.packageName = files[0].Name.Name
+
+	for _, file := range files {
+		gen.collectStructs(file)
+	}
+	for _, file := range files {
+		gen.collectDependers(file)
+	}
+	for _, file := range files {
+		gen.collectAfterInjecters(file)
+	}
+
+	if 0 < len(gen.problems) {
+		return nil, gen.warnings, fmt.Errorf("%s", strings.Join(gen.problems, "\n"))
+	}
+
+	if cfg.check {
+		registrations := cfg.registrations
+		if 0 >= len(registrations) {
+			registrations = []string{cfg.dir}
+		}
+
+		known, err := registeredNames(registrations, cfg.output)
+		if nil != err {
+			return nil, gen.warnings, err
+		}
+		for _, name := range cfg.names {
+			known[name] = struct{}{}
+		}
+
+		if err := gen.checkNames(known); nil != err {
+			return nil, gen.warnings, err
+		}
+	}
+
+	code, err = gen.code()
+	if nil != err {
+		return nil, gen.warnings, err
+	}
+
+	return code, gen.warnings, nil
+}
+
+
+// parseDir parses the (non-test) Go files in the directory, that would be built (given the
+// build constraints), other than the output file.
+func parseDir(fset *token.FileSet, dir string, output string) ([]*ast.File, error) {
+	entries, err := os.ReadDir(dir)
+	if nil != err {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || name == filepath.Base(output) {
+			continue
+		}
+
+		if match, err := build.Default.MatchFile(dir, name); nil != err || !match {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if nil != err {
+			return nil, err
+		}
+
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+
+// collectStructs finds the struct types in the file, and makes an injectable for each one
+// with `inject` struct tags.
+func (gen *generator) collectStructs(file *ast.File) {
+	fileImports := importsOf(file)
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || token.TYPE != genDecl.Tok {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || nil != typeSpec.TypeParams {
+				continue
+			}
+
+			gen.structs[typeSpec.Name.Name] = structType
+
+			var fields []injectField
+			for _, field := range structType.Fields.List {
+				if nil == field.Tag {
+					continue
+				}
+
+				tag, err := strconv.Unquote(field.Tag.Value)
+				if nil != err {
+					continue
+				}
+
+				dependencyName := reflect.StructTag(tag).Get("inject")
+				if "" == dependencyName {
+					continue
+				}
+
+				if strings.HasPrefix(dependencyName, "group:") || strings.HasPrefix(dependencyName, "prefix:") || strings.HasPrefix(dependencyName, "@") || strings.Contains(dependencyName, ",") {
+					gen.unsupported[typeSpec.Name.Name] = struct{}{}
+					gen.warnings = append(gen.warnings, fmt.Sprintf("%s: skipping %s, since `inject:%q` needs to be injected with InjectContext", gen.fset.Position(field.Pos()), typeSpec.Name.Name, dependencyName))
+					continue
+				}
+
+				if isContainerType(field.Type, fileImports, "Lazy", "Provider") {
+					gen.unsupported[typeSpec.Name.Name] = struct{}{}
+					gen.warnings = append(gen.warnings, fmt.Sprintf("%s: skipping %s, since a container.Lazy or container.Provider field needs to be injected with InjectContext", gen.fset.Position(field.Pos()), typeSpec.Name.Name))
+					continue
+				}
+
+				names := field.Names
+				if 0 >= len(names) {
+					names = []*ast.Ident{ast.NewIdent(embeddedName(field.Type))}
+				}
+
+				typeExpr, imports, err := gen.typeExpr(field.Type, fileImports)
+				if nil != err {
+					gen.problems = append(gen.problems, fmt.Sprintf("%s: %v", gen.fset.Position(field.Pos()), err))
+					continue
+				}
+
+				for _, name := range names {
+					requiredBy := "*" + gen.packageName + "." + typeSpec.Name.Name + "." + name.Name
+
+					if !ast.IsExported(name.Name) {
+						gen.problems = append(gen.problems, fmt.Sprintf("%s: cannot inject dependency %q into %s because the field is unexported", gen.fset.Position(name.Pos()), dependencyName, requiredBy))
+						continue
+					}
+
+					fields = append(fields, injectField{
+						name:name.Name,
+						dependencyName:dependencyName,
+						typeExpr:typeExpr,
+						anyType:"interface{}" == typeExpr || "any" == typeExpr,
+						requiredBy:requiredBy,
+						imports:imports,
+					})
+				}
+			}
+
+			if 0 < len(fields) {
+				gen.add(typeSpec.Name.Name).fields = fields
+			}
+		}
+	}
+}
+
+
+// collectDependers finds the Dependencies methods in the file.
+func (gen *generator) collectDependers(file *ast.File) {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || nil == funcDecl.Recv || "Dependencies" != funcDecl.Name.Name {
+			continue
+		}
+		if 0 < funcDecl.Type.Params.NumFields() || nil == funcDecl.Type.Results || 1 != funcDecl.Type.Results.NumFields() {
+			continue
+		}
+
+		receiver := funcDecl.Recv.List[0]
+
+		recvType := receiver.Type
+		if star, ok := recvType.(*ast.StarExpr); ok {
+			recvType = star.X
+		}
+		recvIdent, ok := recvType.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		typeName := recvIdent.Name
+
+		dependencies := gen.dependenciesType(funcDecl, receiver)
+		if "" == dependencies {
+			gen.unsupported[typeName] = struct{}{}
+			gen.warnings = append(gen.warnings, fmt.Sprintf("%s: skipping %s, since what its Dependencies method returns could not be figured out", gen.fset.Position(funcDecl.Pos()), typeName))
+			continue
+		}
+
+		if _, ok := gen.unsupported[dependencies]; ok {
+			gen.unsupported[typeName] = struct{}{}
+			gen.warnings = append(gen.warnings, fmt.Sprintf("%s: skipping %s, since %s is skipped", gen.fset.Position(funcDecl.Pos()), typeName, dependencies))
+			continue
+		}
+
+		if _, ok := gen.injectables[dependencies]; !ok {
+			// Nothing in it to inject.
+			continue
+		}
+
+		gen.add(typeName).dependencies = dependencies
+	}
+}
+
+
+// collectAfterInjecters finds the AfterInject methods (see container.AfterInjecter) in the
+// file, of the types that get a generated inject func.
+func (gen *generator) collectAfterInjecters(file *ast.File) {
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || nil == funcDecl.Recv || "AfterInject" != funcDecl.Name.Name {
+			continue
+		}
+		if 0 < funcDecl.Type.Params.NumFields() || nil == funcDecl.Type.Results || 1 != funcDecl.Type.Results.NumFields() {
+			continue
+		}
+
+		recvType := funcDecl.Recv.List[0].Type
+		if star, ok := recvType.(*ast.StarExpr); ok {
+			recvType = star.X
+		}
+		recvIdent, ok := recvType.(*ast.Ident)
+		if !ok {
+			continue
+		}
+
+		if thing, ok := gen.injectables[recvIdent.Name]; ok {
+			thing.afterInject = true
+		}
+	}
+}
+
+
+// dependenciesType returns the name of the struct type that the Dependencies method returns a
+// pointer to, if the Dependencies method ends with something like:
+//
+//	return &s.dependencies
+//
+// Else "" (empty string) is returned.
+func (gen *generator) dependenciesType(funcDecl *ast.FuncDecl, receiver *ast.Field) string {
+	if nil == funcDecl.Body || 0 >= len(funcDecl.Body.List) || 1 != len(receiver.Names) {
+		return ""
+	}
+
+	recvType := receiver.Type
+	if star, ok := recvType.(*ast.StarExpr); ok {
+		recvType = star.X
+	}
+
+	returnStmt, ok := funcDecl.Body.List[len(funcDecl.Body.List)-1].(*ast.ReturnStmt)
+	if !ok || 1 != len(returnStmt.Results) {
+		return ""
+	}
+
+	unary, ok := returnStmt.Results[0].(*ast.UnaryExpr)
+	if !ok || token.AND != unary.Op {
+		return ""
+	}
+
+	selector, ok := unary.X.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	if ident, ok := selector.X.(*ast.Ident); !ok || receiver.Names[0].Name != ident.Name {
+		return ""
+	}
+
+	structType, ok := gen.structs[recvType.(*ast.Ident).Name]
+	if !ok {
+		return ""
+	}
+
+	for _, field := range structType.Fields.List {
+		for _, name := range field.Names {
+			if selector.Sel.Name != name.Name {
+				continue
+			}
+
+			if ident, ok := field.Type.(*ast.Ident); ok {
+				if _, ok := gen.structs[ident.Name]; ok {
+					return ident.Name
+				}
+			}
+
+			return ""
+		}
+	}
+
+	return ""
+}
+
+
+// add returns the injectable for the type, making it if it does not already exist.
+func (gen *generator) add(typeName string) *injectable {
+	if thing, ok := gen.injectables[typeName]; ok {
+		return thing
+	}
+
+	thing := injectable{
+		typeName:typeName,
+	}
+
+	gen.injectables[typeName] = &thing
+	gen.order = append(gen.order, typeName)
+
+	return &thing
+}
+
+
+// typeExpr returns the Go code for the type, along with the imports it uses.
+func (gen *generator) typeExpr(expr ast.Expr, fileImports map[string]string) (string, map[string]string, error) {
+	var err error
+
+	imports := map[string]string{}
+
+	ast.Inspect(expr, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+
+		ident, ok := selector.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		importPath, ok := fileImports[ident.Name]
+		if !ok {
+			err = fmt.Errorf("cannot find the import for %q (if the package's name is not the last part of its import path, then import it with a name)", ident.Name)
+			return false
+		}
+
+		imports[ident.Name] = importPath
+
+		return false
+	})
+	if nil != err {
+		return "", nil, err
+	}
+
+	var buffer bytes.Buffer
+	if err := printer.Fprint(&buffer, gen.fset, expr); nil != err {
+		return "", nil, err
+	}
+
+	return buffer.String(), imports, nil
+}
+
+
+// isContainerType returns whether the type expression is of one of the (generic) types, with those
+// names, of the github.com/reiver/go-container package. As in:
+//
+//	container.Lazy[*sql.DB]
+func isContainerType(expr ast.Expr, fileImports map[string]string, names ...string) bool {
+	index, ok := expr.(*ast.IndexExpr)
+	if !ok {
+		return false
+	}
+
+	selector, ok := index.X.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	ident, ok := selector.X.(*ast.Ident)
+	if !ok || containerImportPath != fileImports[ident.Name] {
+		return false
+	}
+
+	for _, name := range names {
+		if name == selector.Sel.Name {
+			return true
+		}
+	}
+
+	return false
+}
+
+
+// checkNames makes sure that each name in an `inject` struct tag is one of the known names.
+//
+// The error returned lists the missing names the same way a DependenciesNotFoundComplainer does.
+func (gen *generator) checkNames(known map[string]struct{}) error {
+	missing := map[string][]string{}
+
+	for _, typeName := range gen.order {
+		for _, field := range gen.injectables[typeName].fields {
+			if _, ok := known[field.dependencyName]; ok {
+				continue
+			}
+
+			missing[field.dependencyName] = append(missing[field.dependencyName], field.requiredBy)
+		}
+	}
+
+	if 0 >= len(missing) {
+		return nil
+	}
+
+	var names []string
+	for name := range missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		requiredBy := missing[name]
+		sort.Strings(requiredBy)
+
+		parts = append(parts, fmt.Sprintf("%q required by %s", name, strings.Join(requiredBy, ", ")))
+	}
+
+	return fmt.Errorf("Dependencies not found: %s", strings.Join(parts, "; "))
+}
+
+
+// code returns the (formatted) generated code.
+func (gen *generator) code() ([]byte, error) {
+	var body bytes.Buffer
+
+	imports := map[string]string{
+		"context":"context",
+		"container":containerImportPath,
+	}
+	addImport := func(name string, importPath string) error {
+		if other, ok := imports[name]; ok && other != importPath {
+			return fmt.Errorf("%q is imported as both %q and %q", name, other, importPath)
+		}
+		imports[name] = importPath
+		return nil
+	}
+
+	for _, typeName := range gen.order {
+		if _, ok := gen.unsupported[typeName]; ok {
+			continue
+		}
+
+		thing := gen.injectables[typeName]
+
+		fmt.Fprintf(&body, "\n// %s injects the dependencies of a *%s. It does what container.Container's\n", funcName(typeName), typeName)
+		fmt.Fprintf(&body, "// InjectContext method does, but without reflection.\n")
+		fmt.Fprintf(&body, "func %s(ctx context.Context, c container.Container, thing *%s) error {\n", funcName(typeName), typeName)
+
+		if "" != thing.dependencies {
+			fmt.Fprintf(&body, "\tif dependencies, ok := thing.Dependencies().(*%s); ok {\n", thing.dependencies)
+			fmt.Fprintf(&body, "\t\tif err := %s(ctx, c, dependencies); nil != err {\n", funcName(thing.dependencies))
+			fmt.Fprintf(&body, "\t\t\treturn err\n")
+			fmt.Fprintf(&body, "\t\t}\n")
+			fmt.Fprintf(&body, "\t}\n")
+		}
+
+		for _, field := range thing.fields {
+			fmt.Fprintf(&body, "\t{\n")
+			fmt.Fprintf(&body, "\t\tdependency, err := c.GetContext(ctx, %q)\n", field.dependencyName)
+			fmt.Fprintf(&body, "\t\tif nil != err {\n")
+			fmt.Fprintf(&body, "\t\t\treturn err\n")
+			fmt.Fprintf(&body, "\t\t}\n")
+			if field.anyType {
+				fmt.Fprintf(&body, "\t\tthing.%s = dependency\n", field.name)
+			} else {
+				if err := addImport("fmt", "fmt"); nil != err {
+					return nil, err
+				}
+				for name, importPath := range field.imports {
+					if err := addImport(name, importPath); nil != err {
+						return nil, err
+					}
+				}
+
+				fmt.Fprintf(&body, "\t\tvalue, ok := dependency.(%s)\n", field.typeExpr)
+				fmt.Fprintf(&body, "\t\tif !ok {\n")
+				fmt.Fprintf(&body, "\t\t\treturn fmt.Errorf(\"dependency %%q is a %%T, which cannot be injected into %%s (a %%s): %%w\", %q, dependency, %q, %q, container.ErrWrongType)\n", field.dependencyName, field.requiredBy, field.typeExpr)
+				fmt.Fprintf(&body, "\t\t}\n")
+				fmt.Fprintf(&body, "\t\tthing.%s = value\n", field.name)
+			}
+			fmt.Fprintf(&body, "\t}\n")
+		}
+
+		if thing.afterInject {
+			if err := addImport("fmt", "fmt"); nil != err {
+				return nil, err
+			}
+
+			fmt.Fprintf(&body, "\tif err := thing.AfterInject(); nil != err {\n")
+			fmt.Fprintf(&body, "\t\treturn fmt.Errorf(\"%%w into %%s: %%w\", container.ErrAfterInject, %q, err)\n", "*"+gen.packageName+"."+typeName)
+			fmt.Fprintf(&body, "\t}\n")
+		}
+
+		fmt.Fprintf(&body, "\treturn nil\n")
+		fmt.Fprintf(&body, "}\n")
+	}
+
+	var names []string
+	for name := range imports {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return imports[names[i]] < imports[names[j]]
+	})
+
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "// Code generated by containergen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buffer, "package %s\n\n", gen.packageName)
+	fmt.Fprintf(&buffer, "import (\n")
+	for _, name := range names {
+		importPath := imports[name]
+		if name == importName(importPath) {
+			fmt.Fprintf(&buffer, "\t%q\n", importPath)
+		} else {
+			fmt.Fprintf(&buffer, "\t%s %q\n", name, importPath)
+		}
+	}
+	fmt.Fprintf(&buffer, ")\n")
+
+	buffer.Write(body.Bytes())
+
+	return format.Source(buffer.Bytes())
+}
+
+
+// registeredNames returns the names registered in the Go files of the directories (as far as
+// can be told by looking for calls to Register, RegisterProvider, RegisterScoped, RegisterTransient,
+// Alias and NewModule).
+func registeredNames(dirs []string, output string) (map[string]struct{}, error) {
+	names := map[string]struct{}{}
+
+	for _, dir := range dirs {
+		fset := token.NewFileSet()
+
+		files, err := parseDir(fset, dir, output)
+		if nil != err {
+			return nil, err
+		}
+
+		for _, file := range files {
+			ast.Inspect(file, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+
+				var funcName string
+				var prefix string
+				switch fun := call.Fun.(type) {
+				case *ast.Ident:
+					funcName = fun.Name
+				case *ast.SelectorExpr:
+					funcName = fun.Sel.Name
+					prefix = namespacePrefix(fun.X)
+				}
+
+				switch funcName {
+				case "Register", "RegisterProvider", "RegisterScoped", "RegisterTransient", "Alias":
+					if 0 < len(call.Args) {
+						if name, ok := stringLiteral(call.Args[0]); ok {
+							names[prefix+name] = struct{}{}
+						}
+					}
+				case "NewModule":
+					if 1 < len(call.Args) {
+						if provides, ok := call.Args[1].(*ast.CompositeLit); ok {
+							for _, elt := range provides.Elts {
+								if name, ok := stringLiteral(elt); ok {
+									names[name] = struct{}{}
+								}
+							}
+						}
+					}
+				}
+
+				return true
+			})
+		}
+	}
+
+	return names, nil
+}
+
+
+// namespacePrefix returns the prefix, if the expression is something like:
+//
+//	Container.Namespace("kitchen.")
+//
+// Else "" (empty string) is returned.
+func namespacePrefix(expr ast.Expr) string {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || 1 != len(call.Args) {
+		return ""
+	}
+
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || "Namespace" != selector.Sel.Name {
+		return ""
+	}
+
+	prefix, _ := stringLiteral(call.Args[0])
+
+	return namespacePrefix(selector.X) + prefix
+}
+
+
+// stringLiteral returns the value of the expression, if it is a string literal.
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || token.STRING != lit.Kind {
+		return "", false
+	}
+
+	s, err := strconv.Unquote(lit.Value)
+	if nil != err {
+		return "", false
+	}
+
+	return s, true
+}
+
+
+// importsOf returns the imports of the file, keyed by the name they are referred to by.
+func importsOf(file *ast.File) map[string]string {
+	imports := map[string]string{}
+
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if nil != err {
+			continue
+		}
+
+		name := importName(importPath)
+		if nil != spec.Name {
+			name = spec.Name.Name
+		}
+
+		imports[name] = importPath
+	}
+
+	return imports
+}
+
+
+// importName returns the name an import is (probably) referred to by, if it is not given a name.
+//
+// This is a guess, based on the import path, as in:
+//
+//	"net/http"                     -> http
+//	"github.com/reiver/go-container" -> container
+//	"gopkg.in/yaml.v3"             -> yaml
+func importName(importPath string) string {
+	name := path.Base(importPath)
+
+	if i := strings.Index(name, ".v"); 0 < i {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	name = strings.TrimSuffix(name, "-go")
+
+	return name
+}
+
+
+// embeddedName returns the field name of an embedded field of the type.
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	default:
+		return ""
+	}
+}
+
+
+// funcName returns the name of the generated inject func for the type.
+func funcName(typeName string) string {
+	runes := []rune(typeName)
+	runes[0] = unicode.ToUpper(runes[0])
+
+	return "inject" + string(runes)
+}
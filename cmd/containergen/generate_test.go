@@ -0,0 +1,232 @@
+package main
+
+
+import (
+	"testing"
+
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+
+const source_TestGenerate = `package billing
+
+import (
+	"log"
+
+	"github.com/reiver/go-container"
+)
+
+type serviceDependencies struct {
+	Logger *log.Logger ` + "`inject:\"logger\"`" + `
+	Config interface{} ` + "`inject:\"config\"`" + `
+}
+
+type Service struct {
+	dependencies serviceDependencies
+	Name string ` + "`inject:\"service-name\"`" + `
+}
+
+func (s *Service) Dependencies() interface{} {
+	return &s.dependencies
+}
+
+func (s *Service) AfterInject() error {
+	return nil
+}
+
+type Router struct {
+	Routes []string ` + "`inject:\"group:routes\"`" + `
+}
+
+type Mailer struct {
+	Logger container.Lazy[*log.Logger] ` + "`inject:\"logger\"`" + `
+}
+
+func register(c container.Container) {
+	c.Register("logger", log.Default())
+	c.RegisterProvider("config", nil)
+	c.Namespace("billing.").Register("name", "billing")
+	c.Alias("service-name", "billing.name")
+}
+`
+
+
+func TestGenerate(t *testing.T) {
+
+	dir := t.TempDir()
+
+	if err := os.WriteFile(filepath.Join(dir, "billing.go"), []byte(source_TestGenerate), 0644); nil != err {
+		t.Errorf("Received an error when writing the source: (%T) %v.", err, err)
+		return
+	}
+
+	code, warnings, err := generate(config{dir:dir, output:"containergen_gen.go", check:true})
+	if nil != err {
+		t.Errorf("Received an error when generating: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := 2, len(warnings); expected != actual {
+		t.Errorf("Expected %d warnings, but actually got %d: %q", expected, actual, warnings)
+		return
+	}
+
+	for _, expected := range []string{
+		"// Code generated by containergen. DO NOT EDIT.",
+		"package billing",
+		`"log"`,
+		`"github.com/reiver/go-container"`,
+		"func injectServiceDependencies(ctx context.Context, c container.Container, thing *serviceDependencies) error {",
+		`dependency, err := c.GetContext(ctx, "logger")`,
+		"value, ok := dependency.(*log.Logger)",
+		"thing.Config = dependency",
+		"func injectService(ctx context.Context, c container.Container, thing *Service) error {",
+		"if dependencies, ok := thing.Dependencies().(*serviceDependencies); ok {",
+		"container.ErrWrongType",
+		"if err := thing.AfterInject(); nil != err {",
+		"container.ErrAfterInject",
+	} {
+		if !strings.Contains(string(code), expected) {
+			t.Errorf("Expected the generated code to contain %q, but it didn't:\n%s", expected, code)
+			return
+		}
+	}
+
+	if strings.Contains(string(code), "injectRouter") {
+		t.Errorf("Expected Router to be skipped, but it wasn't:\n%s", code)
+		return
+	}
+	if strings.Contains(string(code), "injectMailer") {
+		t.Errorf("Expected Mailer to be skipped, but it wasn't:\n%s", code)
+		return
+	}
+}
+
+
+const test_TestGenerateRuns = `package billing
+
+import (
+	"context"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/reiver/go-container"
+)
+
+func TestInjectService(t *testing.T) {
+
+	c := container.New()
+	c.Register("logger", log.Default())
+	c.Register("config", 5)
+	c.Register("service-name", "billing")
+
+	var service Service
+	if err := injectService(context.Background(), c, &service); nil != err {
+		t.Fatalf("Received an error when injecting: (%T) %v.", err, err)
+	}
+
+	if expected, actual := log.Default(), service.dependencies.Logger; expected != actual {
+		t.Fatalf("Expected the logger to have been injected, but it wasn't.")
+	}
+	if expected, actual := interface{}(5), service.dependencies.Config; expected != actual {
+		t.Fatalf("Expected config %v, but actually got %v.", expected, actual)
+	}
+	if expected, actual := "billing", service.Name; expected != actual {
+		t.Fatalf("Expected name %q, but actually got %q.", expected, actual)
+	}
+
+	wrong := container.New()
+	wrong.Register("logger", "not a logger")
+	wrong.Register("config", 5)
+	wrong.Register("service-name", "billing")
+
+	if err := injectService(context.Background(), wrong, new(Service)); !errors.Is(err, container.ErrWrongType) {
+		t.Fatalf("Expected a WrongTypeComplainer, but actually got: (%T) %v.", err, err)
+	}
+}
+`
+
+
+// TestGenerateRuns builds the generated code (against this module) and runs a generated inject func.
+func TestGenerateRuns(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("skipping building the generated code in short mode")
+	}
+
+	goCommand, err := exec.LookPath("go")
+	if nil != err {
+		t.Skip("skipping building the generated code, since the go command was not found")
+	}
+
+	module, err := filepath.Abs(filepath.Join("..", ".."))
+	if nil != err {
+		t.Errorf("Received an error when finding this module: (%T) %v.", err, err)
+		return
+	}
+
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"go.mod":"module example.com/billing\n\ngo 1.21\n\nrequire github.com/reiver/go-container v0.0.0\n\nreplace github.com/reiver/go-container => " + module + "\n",
+		"billing.go":source_TestGenerate,
+		"billing_test.go":test_TestGenerateRuns,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); nil != err {
+			t.Errorf("Received an error when writing %s: (%T) %v.", name, err, err)
+			return
+		}
+	}
+
+	code, _, err := generate(config{dir:dir, output:"containergen_gen.go", check:true})
+	if nil != err {
+		t.Errorf("Received an error when generating: (%T) %v.", err, err)
+		return
+	}
+	if err := os.WriteFile(filepath.Join(dir, "containergen_gen.go"), code, 0644); nil != err {
+		t.Errorf("Received an error when writing the generated code: (%T) %v.", err, err)
+		return
+	}
+
+	cmd := exec.Command(goCommand, "test", "-vet=off", "-run", "TestInjectService", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off", "GOTOOLCHAIN=local")
+	if output, err := cmd.CombinedOutput(); nil != err {
+		t.Errorf("Expected the generated code to build and inject, but it didn't: %v\n%s\n%s", err, output, code)
+		return
+	}
+}
+
+
+func TestGenerateMissingNames(t *testing.T) {
+
+	dir := t.TempDir()
+
+	source := strings.Replace(source_TestGenerate, `c.Register("logger", log.Default())`, "", 1)
+
+	if err := os.WriteFile(filepath.Join(dir, "billing.go"), []byte(source), 0644); nil != err {
+		t.Errorf("Received an error when writing the source: (%T) %v.", err, err)
+		return
+	}
+
+	_, _, err := generate(config{dir:dir, output:"containergen_gen.go", check:true})
+	if nil == err {
+		t.Errorf("Expected an error, but did not receive one.")
+		return
+	}
+
+	if expected, actual := `Dependencies not found: "logger" required by *billing.serviceDependencies.Logger`, err.Error(); expected != actual {
+		t.Errorf("Expected error message %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	if _, _, err := generate(config{dir:dir, output:"containergen_gen.go", names:[]string{"logger"}, check:true}); nil != err {
+		t.Errorf("Expected no error when the missing name is given with -names, but received: (%T) %v.", err, err)
+		return
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+
+func main() {
+	var cfg config
+	var registrations string
+	var names string
+
+	flag.StringVar(&cfg.dir, "dir", ".", "the directory of the package to generate code for")
+	flag.StringVar(&cfg.output, "output", "containergen_gen.go", "the file to write the generated code to")
+	flag.StringVar(&registrations, "registrations", "", "comma-separated directories to look for registrations in (default: the -dir directory)")
+	flag.StringVar(&names, "names", "", "comma-separated names that are registered in some other way")
+	flag.BoolVar(&cfg.check, "check", true, "whether to fail when a name is not registered")
+	flag.Parse()
+
+	cfg.registrations = splitList(registrations)
+	cfg.names = splitList(names)
+
+	code, warnings, err := generate(cfg)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "containergen: warning: %s\n", warning)
+	}
+	if nil != err {
+		fmt.Fprintf(os.Stderr, "containergen: %v\n", err)
+		os.Exit(1)
+	}
+
+	output := cfg.output
+	if !filepath.IsAbs(output) {
+		output = filepath.Join(cfg.dir, output)
+	}
+
+	if err := os.WriteFile(output, code, 0644); nil != err {
+		fmt.Fprintf(os.Stderr, "containergen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+
+// splitList splits a comma-separated list, skipping anything empty.
+func splitList(s string) []string {
+	var list []string
+
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); "" != item {
+			list = append(list, item)
+		}
+	}
+
+	return list
+}
@@ -2,23 +2,100 @@ package container
 
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"reflect"
-	"strings"
+	"runtime"
+	"sync"
+	"sync/atomic"
 )
 
 
 // Container is an abstration that represents a 'dependency injection container'.
 //
 // Use the New func to get a new (dependenc injection) container.
+//
+// The GetContext and InjectContext methods are like the Get and Inject methods,
+// except that the context.Context passed to them is passed along to any providers
+// (see RegisterProvider). If the context.Context is canceled (or its deadline is
+// exceeded) then a CanceledComplainer is returned.
+//
+// A struct field with the `inject:"@container"` struct tag gets a Resolver, which
+// can be used to resolve more dependencies later on (such as by names only known at
+// runtime).
+//
+// When injecting into a struct field of a func or chan type, the dependency does not
+// need to be of exactly that type. A func is adapted into the type of the field, if the
+// parameters and results are compatible. A channel is adapted into a (possibly directional)
+// channel with the same element type, as in a chan Event into a <-chan Event. And a method
+// of the dependency can be injected into a func field, by giving its name with a "method="
+// option, as in `inject:"event-handler,method=Handle"`. (A method of a dependency that is
+// not a func is never used without a "method=" option.)
+//
+// The Invoke and Construct methods call funcs (such as constructors) with their
+// parameters resolved from the container.
+//
+// The New method makes a new struct and injects its dependencies into it.
+//
+// The NewScope method returns a child container (see Scope).
+//
+// The Close method closes the dependencies the container constructed (see Close).
+//
+// The Install method installs modules (see Module).
+//
+// The Snapshot and Restore methods save, and later put back, what is registered with
+// the container (which is mostly useful for tests).
+//
+// The Seal method makes it so nothing more can be registered with the container.
 type Container interface {
 	Register(string, interface{}) error
+	RegisterProvider(string, func(context.Context) (interface{}, error)) error
+	RegisterScoped(string, func(context.Context) (interface{}, error)) error
+	RegisterTransient(string, func(context.Context) (interface{}, error)) error
+
+	Replace(string, interface{}) error
+
+	Install(...Module) error
+
+	Snapshot(...string) Snapshot
+	Restore(Snapshot) error
+
+	Decorate(string, func(interface{}) (interface{}, error)) error
+
+	Alias(string, string) error
+
+	RegisterInGroup(string, interface{}) error
+	RegisterNamedInGroup(string, string, interface{}) error
+
+	List(string) []string
+	Namespace(string) Container
+
+	Has(string) bool
+	Names() []string
+	Describe(string) (Description, error)
 
 	Get(string) (interface{}, error)
+	GetContext(context.Context, string) (interface{}, error)
 
 	Inject(interface{}) error
+	InjectContext(context.Context, interface{}) error
+
+	Invoke(interface{}, ...string) ([]interface{}, error)
+	InvokeContext(context.Context, interface{}, ...string) ([]interface{}, error)
+	Construct(interface{}, ...string) (interface{}, error)
+	ConstructContext(context.Context, interface{}, ...string) (interface{}, error)
+
+	New(interface{}) (interface{}, error)
+	NewContext(context.Context, interface{}) (interface{}, error)
+
+	NewScope() Scope
+
+	Close() error
+
+	Seal()
 }
 
 type internalContainerDependencies struct {
@@ -26,8 +103,27 @@ type internalContainerDependencies struct {
 }
 
 type internalContainer struct {
-	registry map[string]interface{}
+	mutex *sync.RWMutex
+	registry map[string]*registration
+	aliases map[string]string
+	groups map[string][]groupMember
+
+	// decorated holds this scope's own decorated copies of registrations inherited from
+	// its ancestors (see the Decorate method), keyed by the inherited registration.
+	decorated map[*registration]*registration
+
+	parent *internalContainer
+	namespace string
+	scoped *scopedInstances
+
+	// closed is whether the Close method has been called. (It is a pointer since namespaces
+	// (see Namespace) share it.)
+	closed *atomic.Bool
+
+	sealed *atomic.Pointer[sealedRegistry]
 	dependencies internalContainerDependencies
+	collectAllErrors bool
+	strict bool
 }
 
 
@@ -37,18 +133,33 @@ func (container *internalContainer) Dependencies() interface{} {
 
 
 // New returns a new 'dependency injection container'.
-func New() Container {
+//
+// How the container behaves can be changed by passing it options. For example:
+//
+//	Container := container.New(container.CollectAllErrors())
+func New(options ...Option) Container {
 	logger := log.New(ioutil.Discard, "dependency injection container> ", log.Lshortfile)
 
-	registry  := make(map[string]interface{})
+	registry  := make(map[string]*registration)
 
 	container := internalContainer{
+		mutex:new(sync.RWMutex),
 		registry:registry,
+		aliases:make(map[string]string),
+		groups:make(map[string][]groupMember),
+		decorated:make(map[*registration]*registration),
+		scoped:newScopedInstances(),
+		closed:new(atomic.Bool),
+		sealed:new(atomic.Pointer[sealedRegistry]),
 		dependencies:internalContainerDependencies{
 			Logger:logger,
 		},
 	}
 
+	for _, option := range options {
+		option(&container)
+	}
+
 	return &container
 }
 
@@ -59,50 +170,439 @@ func (container *internalContainer) Register(dependencyName string, dependency i
 
 	logger.Printf("[BEGIN] Register(%q, <dependency> %T)", dependencyName, dependency)
 
+	if err := container.register(dependencyName, newRegistration(dependency)); nil != err {
+		logger.Printf("[END]   Register(%q, <dependency> %T) with ERROR: %q", dependencyName, dependency, err)
+		return err
+	}
+
+	logger.Printf("[END]   Register(%q, <dependency> %T)", dependencyName, dependency)
+
+	return nil
+}
+
+
+// RegisterProvider registers a dependency that is constructed lazily.
+//
+// The provider is called (at most once) the first time the dependency is needed,
+// by Get, GetContext, Inject or InjectContext. What it returns is then used from
+// then on. If the provider returns an error, then that error is returned (wrapped
+// in a ProblemProvidingDependencyComplainer) and the provider will be called again
+// the next time the dependency is needed.
+//
+// The context.Context passed to the provider is (derived from) the one passed to GetContext
+// or InjectContext. (Get and Inject pass context.Background().) For example:
+//
+//	err := Container.RegisterProvider("db", func(ctx context.Context) (interface{}, error) {
+//		return sql.Open(driverName, dataSourceName)
+//	})
+//
+// While the provider is being called, anything else that needs the dependency waits for it
+// (or for its own context.Context to be done). A provider that needs other dependencies should
+// get them with that context.Context (as in, with GetContext or InjectContext). That way, if
+// the provider (directly, or through other providers) needs its own dependency, a CycleComplainer
+// is returned, rather than it waiting forever.
+//
+// Once the dependency has been provided, the container's Close method closes it. (See Close.)
+func (container *internalContainer) RegisterProvider(dependencyName string, provider func(context.Context) (interface{}, error)) error {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] RegisterProvider(%q, <provider>)", dependencyName)
+
+	reg := newProviderRegistration(provider)
+	reg.onProvided = container.constructed
+
+	if err := container.register(dependencyName, reg); nil != err {
+		logger.Printf("[END]   RegisterProvider(%q, <provider>) with ERROR: %q", dependencyName, err)
+		return err
+	}
+
+	logger.Printf("[END]   RegisterProvider(%q, <provider>)", dependencyName)
+
+	return nil
+}
+
+// RegisterTransient registers a dependency that is constructed anew each time it is needed.
+//
+// Each time the dependency is needed, by Get, GetContext, Inject or InjectContext (or by calling
+// a Provider[T] that it was injected into), the provider is called, and what it returns is not
+// held on to. If the provider returns an error, then that error is returned (wrapped in a
+// ProblemProvidingDependencyComplainer).
+//
+// Since the container does not hold on to the dependencies it constructs this way, closing
+// them (or whatever else) is up to whatever got them. For example:
+//
+//	err := Container.RegisterTransient("unit-of-work", func(ctx context.Context) (interface{}, error) {
+//		return NewUnitOfWork(ctx)
+//	})
+func (container *internalContainer) RegisterTransient(dependencyName string, provider func(context.Context) (interface{}, error)) error {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] RegisterTransient(%q, <provider>)", dependencyName)
+
+	if err := container.register(dependencyName, newTransientRegistration(provider)); nil != err {
+		logger.Printf("[END]   RegisterTransient(%q, <provider>) with ERROR: %q", dependencyName, err)
+		return err
+	}
+
+	logger.Printf("[END]   RegisterTransient(%q, <provider>)", dependencyName)
+
+	return nil
+}
+
+
+// register puts the registration into the registry, so long as nothing else is already
+// registered with that name.
+//
+// It also makes a note of where the registering was done from. (register is expected
+// to be called directly from the exported method that the caller called.)
+func (container *internalContainer) register(dependencyName string, reg *registration) error {
+	dependencyName = container.qualify(dependencyName)
+
+	if _, file, line, ok := runtime.Caller(2); ok {
+		reg.site = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	container.mutex.Lock()
+	defer container.mutex.Unlock()
+
+	if nil != container.sealed.Load() {
+		return newSealedComplainer(dependencyName)
+	}
+
+	// The container itself is always there, as "@container". (See Resolver.)
+	if containerTag == dependencyName {
+		return newAlreadyRegisteredComplainer(dependencyName)
+	}
+
 	if _,ok := container.registry[dependencyName]; ok {
-		err := newAlreadyRegisteredComplainer(dependencyName)
+		return newAlreadyRegisteredComplainer(dependencyName)
+	}
+	if _,ok := container.aliases[dependencyName]; ok {
+		return newAlreadyRegisteredComplainer(dependencyName)
+	}
 
-		logger.Printf("[END]   Register(%q, <dependency> %T) with ERROR: %q", dependencyName, dependency, err)
+	container.registry[dependencyName] = reg
+
+	return nil
+}
+
+
+// Decorate wraps a registered dependency, so that what Get and Inject (and so on) hand out
+// is whatever the decorator returns.
+//
+// The decorator is passed the dependency (or, if the dependency has already been decorated,
+// what the previous decorator returned). So decorators are applied in the order Decorate is
+// called, with the last one being the outermost. For example:
+//
+//	err := Container.Decorate("user-repository", func(old interface{}) (interface{}, error) {
+//		repository, ok := old.(UserRepository)
+//		if !ok {
+//			return nil, fmt.Errorf("unexpected type %T", old)
+//		}
+//	
+//		return NewCachingUserRepository(repository), nil
+//	})
+//
+// If the dependency was registered with Register (or was registered with RegisterProvider
+// and has already been provided) then the decorator is called right away, and any error it
+// returns is returned by Decorate (as a ProblemProvidingDependencyComplainer). Else the decorator
+// is called when the dependency gets provided, and any error it returns is returned from then.
+//
+// Decorating a scoped dependency (see RegisterScoped) or a transient dependency (see RegisterTransient)
+// affects the instances that are constructed after Decorate is called.
+//
+// Decorating a dependency through a scope (see NewScope) that was registered with one of the
+// scope's ancestors only affects that scope (and its own child scopes). The scope gets its own
+// decorated copy of the registration, and the decorator is called when the dependency is first
+// needed through the scope. (So the ancestor, and other scopes, still get the dependency without
+// the decorator, even after the scope is closed.)
+//
+// If nothing is registered with the name, then a DependenciesNotFoundComplainer is returned.
+// If the container that Decorate is called on is sealed (see Seal), then a SealedComplainer
+// is returned.
+//
+// Decorating, through a scope, a dependency registered with a sealed ancestor is allowed (as long
+// as the scope itself is not sealed). The sealed registration is left as is; only the scope's own
+// decorated copy of it gets the decorator.
+func (container *internalContainer) Decorate(dependencyName string, decorator func(interface{}) (interface{}, error)) error {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] Decorate(%q, <decorator>)", dependencyName)
+
+	reg, err := container.decoratableRegistration(container.qualify(dependencyName))
+	if nil != err {
+		logger.Printf("[END]   Decorate(%q, <decorator>) with ERROR: %q", dependencyName, err)
 		return err
 	}
 
-	container.registry[dependencyName] = dependency
+	if err := reg.addDecorator(dependencyName, decorator); nil != err {
+		logger.Printf("[END]   Decorate(%q, <decorator>) with ERROR: %q", dependencyName, err)
+		return err
+	}
 
-	logger.Printf("[END]   Register(%q, <dependency> %T)", dependencyName, dependency)
+	logger.Printf("[END]   Decorate(%q, <decorator>)", dependencyName)
 
 	return nil
 }
 
 
 func (container *internalContainer) Get(dependencyName string) (interface{}, error) {
+	return container.GetContext(context.Background(), dependencyName)
+}
+
+
+func (container *internalContainer) GetContext(ctx context.Context, dependencyName string) (interface{}, error) {
 
 	logger := container.dependencies.Logger
 
-	logger.Printf("[BEGIN] Get(%q)", dependencyName)
+	logger.Printf("[BEGIN] GetContext(%q)", dependencyName)
 
-	dependency,ok := container.registry[dependencyName]
+	dependency, ok, err := container.resolve(ctx, container.qualify(dependencyName), "")
+	if nil != err {
+		logger.Printf("[END]   GetContext(%q) with ERROR: %q", dependencyName, err)
+		return nil, err
+	}
 	if !ok {
-		err := newDependenciesNotFoundComplainer(dependencyName)
+		err := newDependenciesNotFoundComplainer(container.qualify(dependencyName))
 
-		logger.Printf("[END]   Get(%q) with ERROR: %q", dependencyName, err)
+		logger.Printf("[END]   GetContext(%q) with ERROR: %q", dependencyName, err)
 		return nil, err
 	}
 
-	logger.Printf("[END]   Get(%q)", dependencyName)
+	logger.Printf("[END]   GetContext(%q)", dependencyName)
 
 	return dependency, nil
 }
 
+// decoratableRegistration returns the registration that Decorate should add a decorator to.
+//
+// That is the registration itself, if it was registered with this container. Else (if this
+// container is a scope, and the registration was inherited from one of its ancestors) it is
+// this scope's own decorated copy of the registration, which gets made if there isn't one yet.
+func (container *internalContainer) decoratableRegistration(dependencyName string) (*registration, error) {
+	inherited, owner, ok := container.lookupOwner(dependencyName)
+	if !ok {
+		return nil, newDependenciesNotFoundComplainer(dependencyName)
+	}
+	if container.mutex == owner.mutex {
+		return inherited, nil
+	}
+
+	// What this scope sees (which might already be one of its ancestor scope's own
+	// decorated copies).
+	seen := container.decoratedRegistration(inherited)
+
+	container.mutex.Lock()
+	defer container.mutex.Unlock()
+
+	if nil != container.sealed.Load() {
+		return nil, newSealedComplainer(dependencyName)
+	}
+
+	if local, ok := container.decorated[inherited]; ok {
+		return local, nil
+	}
+
+	var local *registration
+	switch {
+	case seen.scoped:
+		local = newScopedRegistration(seen.construct)
+	case seen.transient:
+		local = newTransientRegistration(seen.construct)
+	default:
+		local = newProviderRegistration(func(ctx context.Context) (interface{}, error) {
+			return seen.get(ctx, dependencyName)
+		})
+	}
+	local.site = seen.site
+
+	container.decorated[inherited] = local
+
+	return local, nil
+}
+
+
+// decoratedRegistration returns the decorated copy of the registration belonging to this
+// container or its closest ancestor that has one (see the Decorate method). Else it returns
+// the registration itself.
+func (container *internalContainer) decoratedRegistration(reg *registration) *registration {
+	// Only scopes have decorated copies. (And a root container has no parent.)
+	for c := container; nil != c && nil != c.parent; c = c.parent {
+		c.mutex.RLock()
+		local, ok := c.decorated[reg]
+		c.mutex.RUnlock()
+
+		if ok {
+			return local
+		}
+	}
+
+	return reg
+}
+
 
-func (container *internalContainer) Inject(thing interface{}) (errr error) {
+// lookup returns the registration with the given name, from this container or
+// (if this container is a scope) from one of its ancestors.
+//
+// If the name is an alias, then the alias is followed. (An alias is looked up from
+// the container it was created in.)
+//
+// If this container is a scope, and it (or one of its ancestor scopes) has its own
+// decorated copy of the registration (see the Decorate method), then that is returned.
+func (container *internalContainer) lookup(dependencyName string) (*registration, bool) {
+	reg, _, ok := container.lookupOwner(dependencyName)
+	if !ok {
+		return nil, false
+	}
+
+	return container.decoratedRegistration(reg), true
+}
+
+
+// lookupOwner returns the registration with the given name (ignoring any decorated copies),
+// along with the container it was registered with.
+func (container *internalContainer) lookupOwner(dependencyName string) (reg *registration, owner *internalContainer, ok bool) {
+	for c := container; nil != c; c = c.parent {
+		var reg *registration
+		var ok bool
+		var target string
+		var isAlias bool
+
+		if snapshot := c.snapshot(); nil != snapshot {
+			reg, ok = snapshot.registry[dependencyName]
+			target, isAlias = snapshot.aliases[dependencyName]
+		} else {
+			c.mutex.RLock()
+			reg, ok = c.registry[dependencyName]
+			target, isAlias = c.aliases[dependencyName]
+			c.mutex.RUnlock()
+		}
+
+		if ok {
+			return reg, c, true
+		}
+		if isAlias {
+			return c.lookupOwner(target)
+		}
+	}
+
+	return nil, nil, false
+}
+
+// resolve returns the dependency registered with the given name.
+//
+// If nothing is registered with that name, then ok is false.
+//
+// If the dependency comes from a provider, then the provider is called (if it hasn't
+// already been). If the context.Context is done, then a CanceledComplainer is returned.
+// If the container (or one of its ancestors) has been closed, then a ClosedComplainer is
+// returned.
+//
+// If requiredBy is not "" (empty string) then a note is made that it required the
+// dependency. (See the Describe method.) Except when the container is sealed and the
+// dependency has already been provided, in which case no locks are taken (see Seal).
+func (container *internalContainer) resolve(ctx context.Context, dependencyName string, requiredBy string) (dependency interface{}, ok bool, err error) {
+	if container.isClosed() {
+		return nil, true, newClosedComplainer(dependencyName)
+	}
+
+	if dependency, ok := container.sealedValue(dependencyName); ok {
+		if err := ctx.Err(); nil != err {
+			return nil, true, newCanceledComplainer(dependencyName, err)
+		}
+
+		return dependency, true, nil
+	}
+
+	reg, ok := container.lookup(dependencyName)
+	if !ok {
+		return nil, false, nil
+	}
+
+	if "" != requiredBy {
+		reg.addDependent(requiredBy)
+	}
+
+	if err := ctx.Err(); nil != err {
+		return nil, true, newCanceledComplainer(dependencyName, err)
+	}
+
+	// Transient dependencies get constructed each time they are needed.
+	if reg.transient {
+		dependency, err = reg.getNew(ctx, dependencyName)
+		if nil != err {
+			return nil, true, err
+		}
+
+		return dependency, true, nil
+	}
+
+	// Scoped dependencies get constructed once per scope.
+	if reg.scoped {
+		reg = container.scopedRegistration(reg)
+	}
+
+	dependency, err = reg.get(ctx, dependencyName)
+	if nil != err {
+		return nil, true, err
+	}
+
+	return dependency, true, nil
+}
+
+
+func (container *internalContainer) Inject(thing interface{}) error {
+	return container.InjectContext(context.Background(), thing)
+}
+
+
+func (container *internalContainer) InjectContext(ctx context.Context, thing interface{}) error {
 
 	logger := container.dependencies.Logger
 
-	logger.Printf("[BEGIN] Inject(??? %T)", thing)
+	logger.Printf("[BEGIN] InjectContext(??? %T)", thing)
+
+	// In strict mode, make sure everything can be set, before
+	// setting anything.
+	if container.strict {
+		if err := container.check(thing, ""); nil != err {
+			logger.Printf("[END]   InjectContext(??? %T) with ERROR: %q", thing, err)
+			return err
+		}
+	}
+
+	if err := container.injectPath(ctx, thing, ""); nil != err {
+		logger.Printf("[END]   InjectContext(??? %T) with ERROR: %q", thing, err)
+		return err
+	}
+
+	logger.Printf("[END]   InjectContext(??? %T)", thing)
+
+	// Return (no errors).
+	return nil
+}
+
+// injectPath does the actual work for the Inject method.
+//
+// The path is where the 'thing' is, relative to what was passed to the
+// Inject method. For example:
+//
+//	["eu-west"][3]
+//
+// The path is included in any complainers that are returned, so that
+// the programmer can figure out where the problem is.
+func (container *internalContainer) injectPath(ctx context.Context, thing interface{}, path string) error {
+
+	logger := container.dependencies.Logger
 
 	// Initialize.
-	dependenciesNotFoundComplainer := newDependenciesNotFoundComplainer()
-	internalDependenciesNotFoundComplainer, _ := dependenciesNotFoundComplainer.(*internalDependenciesNotFoundComplainer)
+	//
+	// (The complainers are only created if there turns out to be a problem.)
+	var dependenciesNotFoundComplainer *internalDependenciesNotFoundComplainer
+	var problems *internalInjectionComplainer
 
 	// If the 'thing' passed to this Inject method fits a Depender (interface)
 	// (and thus has a Dependencies method) then we "inject" what is returned
@@ -111,65 +611,120 @@ func (container *internalContainer) Inject(thing interface{}) (errr error) {
 	//
 	// The point of this is so that a struct can hide where it stores its
 	// dependencies.
+	var otherThing interface{}
 	if depender,ok := thing.(Depender); ok {
-		if otherThing := depender.Dependencies(); nil != otherThing {
-			if err := container.inject(otherThing); nil != err {
+		if otherThing = depender.Dependencies(); nil != otherThing {
+			if err := container.inject(ctx, otherThing, path+".Dependencies()"); nil != err {
 				switch complainer := err.(type) {
 				case DependenciesNotFoundComplainer:
 					logger.Printf("[INSIDE] Inject(??? %T) Intermediate error: %q", thing, complainer)
-					internalDependenciesNotFoundComplainer.concatenate(complainer)
+					if nil == dependenciesNotFoundComplainer {
+						dependenciesNotFoundComplainer = newDependenciesNotFoundComplainer().(*internalDependenciesNotFoundComplainer)
+					}
+					dependenciesNotFoundComplainer.concatenate(complainer)
 					logger.Printf("[INSIDE] Inject(??? %T) Accumulative error: %q", thing, dependenciesNotFoundComplainer)
 				default:
-					logger.Printf("[END]   Inject(??? %T) with ERROR: %q", thing, err)
-					return err
+					if container.stopAt(err) {
+						return dependenciesNotFoundComplainer.with(err)
+					}
+					if nil == problems {
+						problems = newInjectionComplainer().(*internalInjectionComplainer)
+					}
+					problems.insert(err)
 				}
 			}
 		}
 	}
 
 	// Inject the actual thing.
-	if err := container.inject(thing); nil != err {
+	if err := container.inject(ctx, thing, path); nil != err {
 		switch complainer := err.(type) {
 		case DependenciesNotFoundComplainer:
 			logger.Printf("[INSIDE] Inject(??? %T) Intermediate error: %q", thing, complainer)
-			internalDependenciesNotFoundComplainer.concatenate(complainer)
+			if nil == dependenciesNotFoundComplainer {
+				dependenciesNotFoundComplainer = newDependenciesNotFoundComplainer().(*internalDependenciesNotFoundComplainer)
+			}
+			dependenciesNotFoundComplainer.concatenate(complainer)
 			logger.Printf("[INSIDE] Inject(??? %T) Accumulative error: %q", thing, dependenciesNotFoundComplainer)
 		default:
-			logger.Printf("[END]   Inject(??? %T) with ERROR: %q", thing, err)
-			return err
+			if container.stopAt(err) {
+				return dependenciesNotFoundComplainer.with(err)
+			}
+			if nil == problems {
+				problems = newInjectionComplainer().(*internalInjectionComplainer)
+			}
+			problems.insert(err)
 		}
 	}
 
-	// If we had any missing dependencies, then return an error.
-	if 0 < internalDependenciesNotFoundComplainer.len() {
-		err := dependenciesNotFoundComplainer
-
-		logger.Printf("[END]   Inject(??? %T) with ERROR: %q", thing, err)
+	// If we had any problems, then return an error.
+	if err := dependenciesNotFoundComplainer.with(problems.err()); nil != err {
 		return err
 	}
 
-	logger.Printf("[END]   Inject(??? %T)", thing)
+	// Now that everything has been injected, call any AfterInject methods.
+	// (See AfterInjecter.)
+	if afterInjecter, ok := otherThing.(AfterInjecter); ok {
+		if err := afterInjecter.AfterInject(); nil != err {
+			return newAfterInjectComplainer(fmt.Sprintf("%T", otherThing), path+".Dependencies()", err)
+		}
+	}
+	if afterInjecter, ok := thing.(AfterInjecter); ok {
+		if err := afterInjecter.AfterInject(); nil != err {
+			return newAfterInjectComplainer(fmt.Sprintf("%T", thing), path, err)
+		}
+	}
 
-	// Return (no errors).
 	return nil
 }
 
-func (container *internalContainer) inject(thing interface{}) error {
+// resolveTag returns the dependency for an `inject` struct tag.
+//
+// The fieldType and fieldPath are of the struct field the dependency is going to
+// be injected into.
+func (container *internalContainer) resolveTag(ctx context.Context, tag injectTag, requiredBy string, fieldType reflect.Type, fieldPath string) (dependency interface{}, ok bool, err error) {
+	tag.name = container.qualify(tag.name)
+
+	switch {
+	case tag.self:
+		return container.resolver(), true, nil
+	case tag.group:
+		return container.resolveGroup(tag, fieldType, fieldPath)
+	case tag.prefix:
+		return container.resolvePrefix(ctx, tag, requiredBy, fieldType, fieldPath)
+	default:
+		return container.resolve(ctx, tag.name, requiredBy)
+	}
+}
+
+// stopAt returns whether injecting should stop at the given error (rather than
+// continue on, collecting more errors).
+//
+// Missing dependencies are not passed to this. (They are always collected.)
+func (container *internalContainer) stopAt(err error) bool {
+	if !container.collectAllErrors {
+		return true
+	}
+
+	return errors.Is(err, ErrCanceled)
+}
+
+func (container *internalContainer) inject(ctx context.Context, thing interface{}, path string) error {
 
 	value := reflect.ValueOf(thing)
 
 	switch value.Kind() {
 		case reflect.Array:
-			return container.injectArrayOrSlice(thing)
+			return container.injectArrayOrSlice(ctx, thing, path)
 
 		case reflect.Map:
-			return container.injectMap(thing)
+			return container.injectMap(ctx, thing, path)
 
 		case reflect.Slice:
-			return container.injectArrayOrSlice(thing)
+			return container.injectArrayOrSlice(ctx, thing, path)
 
 		case reflect.Ptr:
-			return container.injectPtr(thing)
+			return container.injectPtr(ctx, thing, path)
 
 		default:
 			// Nothing here.
@@ -179,110 +734,119 @@ func (container *internalContainer) inject(thing interface{}) error {
 	return nil
 }
 
-func (container *internalContainer) injectPtr(thing interface{}) error {
+func (container *internalContainer) injectPtr(ctx context.Context, thing interface{}, path string) error {
 
 	// Initialize.
-	dependenciesNotFoundComplainer := newDependenciesNotFoundComplainer().(*internalDependenciesNotFoundComplainer)
+	//
+	// (The complainers are only created if there turns out to be a problem.)
+	var dependenciesNotFoundComplainer *internalDependenciesNotFoundComplainer
+	var problems *internalInjectionComplainer
 
 	// Reflection!
 	value := reflect.ValueOf(thing)
 	x := value.Elem()
-	typeOfX := x.Type()
 
-	// Go through each field of the struct, and if it has a dependency-tag
-	// indicating that a dependency should be injected, then try to do so.
-	numFields := x.NumField()
-	for i:=0; i<numFields; i++ {
-		field := typeOfX.Field(i)
+	if reflect.Struct != x.Kind() {
+		return nil
+	}
+
+	// Go through each field of the struct that has a dependency-tag indicating
+	// that a dependency should be injected, and try to do so.
+	//
+	// Which fields those are is worked out once per type. (See injectionPlan.)
+	for _, field := range planFor(x.Type()).fields {
+
+		fieldPath := field.path
+		if "" != path {
+			fieldPath = path + field.path
+		}
 
-		fieldTag  := field.Tag
+		dependencyName := field.dependencyName
 
-		dependencyName := fieldTag.Get("inject")
+		// A Lazy[T] or Provider[T] struct field gets something that resolves the
+		// dependency later on.
+		if field.deferred && field.exported {
+			if !container.injectDeferred(ctx, x.Field(field.index).Addr().Interface().(deferredInjectable), field, fieldPath) {
+				if nil == dependenciesNotFoundComplainer {
+					dependenciesNotFoundComplainer = newDependenciesNotFoundComplainer().(*internalDependenciesNotFoundComplainer)
+				}
+				dependenciesNotFoundComplainer.insertRequirement(dependencyName, field.requiredBy, fieldPath)
+			}
+			continue
+		}
 
 		// See if the dependency is registered. If it is, then
 		// inject it. Else, make a note of that error.
-		//
-		// Note that reflection will alwys return a value for the
-		// 'struct tag' we ask for. Regardless of whether the
-		// programmer added our specific 'struct tag' or not.
-		//
-		// Even if the programmer doesn't include the specific
-		// 'struct tag' we are interested in, reflection will
-		// still return a value of "" (i.e., empty string) when
-		// we ask it for it.
-		//
-		// Note that this can be potentially confusing because
-		// the programmer could include the specific struct
-		// tag we are interested in, but give it "" (i.e., the
-		// empty string) as a value, and we couldn't tell the
-		// difference between that and when the programmer does
-		// not include the 'struct tag'.
-		//
-		// So, because of this, in the else-clause when we are
-		// checking for errors, we ignore the case where the
-		// 'dependency name' is "" (i.e., the empty string),
-		// and do not consider it an error.
-		if dependency,ok := container.registry[dependencyName]; ok {
-			err := func(value reflect.Value, dependencyName string) (err error) {
+		dependency, ok, err := container.resolveTag(ctx, field.tag, field.requiredBy, field.typ, fieldPath)
+		if ok && nil == err {
+			err = func(value reflect.Value, dependencyName string) (err error) {
+
+				// If the dependency is of a type that cannot be assigned to the
+				// struct field, and it cannot be adapted into the type of the struct
+				// field either (see adapt), then the programmer using the dependency
+				// container is trying to inject a dependency into a struct field of
+				// the wrong type.
+				//
+				// We return a special error for that.
+				dependencyValue := reflect.ValueOf(dependency)
+				if dependencyValue.IsValid() {
+					var ok bool
+					dependencyValue, ok = assignable(dependencyValue, field.typ, field.tag.method)
+					if !ok {
+						return newWrongTypeComplainer(dependencyName, fieldPath)
+					}
+				}
 
 				defer func() {
-
 					if r := recover(); nil != r {
-						// See if we received a message of the form:
-						//
-						//	reflect.Set: value of type ??? is not assignable to type ???
-						//
-						// If we did then we interpret this as the programmer using the
-						// dependency container trying to inject a dependency into a struct
-						// field of the wrong type.
-						//
-						// We return a special error for that.
-						if s, ok := r.(string); ok {
-							needle := "reflect.Set: value of type "
-
-							if strings.HasPrefix(s, needle) {
-								needle = " is not assignable to type "
-
-								if strings.Contains(s, needle) {
-									err = newWrongTypeComplainer(dependencyName)
-									return
-								}
-							}
-						}
-
 						err = fmt.Errorf("%T %v", r, r)
 						return
 					}
-
 				}()
 
-				value.Set( reflect.ValueOf(dependency) )
+				value.Set(dependencyValue)
 
 				return nil
-			}(x.Field(i), dependencyName)
+			}(x.Field(field.index), dependencyName)
+		}
 
-			if nil != err {
-				return newProblemInjectingDependencyComplainer(dependencyName, x.Field(i), err)
+		if ok && nil != err {
+			// If the context.Context is done, then there is no point in going on.
+			if _, canceled := err.(CanceledComplainer); canceled {
+				return err
 			}
-		} else if "" != dependencyName {
-			dependenciesNotFoundComplainer.insert(dependencyName)
-		}
-	}
 
-	// If we had any missing dependencies, then return an error.
-	if 0 < dependenciesNotFoundComplainer.len() {
-		return dependenciesNotFoundComplainer
+			// A WrongTypeComplainer already says what the problem is.
+			// So it does not need to be wrapped.
+			if _, ok := err.(WrongTypeComplainer); !ok {
+				err = newProblemInjectingDependencyComplainer(dependencyName, fieldPath, x.Field(field.index), err)
+			}
+
+			if container.stopAt(err) {
+				return dependenciesNotFoundComplainer.with(err)
+			}
+			if nil == problems {
+				problems = newInjectionComplainer().(*internalInjectionComplainer)
+			}
+			problems.insert(err)
+		} else if !ok {
+			if nil == dependenciesNotFoundComplainer {
+				dependenciesNotFoundComplainer = newDependenciesNotFoundComplainer().(*internalDependenciesNotFoundComplainer)
+			}
+			dependenciesNotFoundComplainer.insertRequirement(dependencyName, field.requiredBy, fieldPath)
+		}
 	}
 
-	// Return (no errors).
-	return nil
+	// If we had any problems, then return an error.
+	return dependenciesNotFoundComplainer.with(problems.err())
 }
 
 
-func (container *internalContainer) injectArrayOrSlice(thing interface{}) error {
+func (container *internalContainer) injectArrayOrSlice(ctx context.Context, thing interface{}, path string) error {
 
 	// Initialize.
 	dependenciesNotFoundComplainer := newDependenciesNotFoundComplainer().(*internalDependenciesNotFoundComplainer)
+	problems := newInjectionComplainer().(*internalInjectionComplainer)
 
 	// Reflection!
 	value := reflect.ValueOf(thing)
@@ -292,54 +856,51 @@ func (container *internalContainer) injectArrayOrSlice(thing interface{}) error
 	for i:=0; i<length; i++ {
 		element := value.Index(i)
 
-		if err := container.Inject(element.Interface()); nil != err {
+		if err := container.injectPath(ctx, element.Interface(), fmt.Sprintf("%s[%d]", path, i)); nil != err {
 			switch complainer := err.(type) {
 			case DependenciesNotFoundComplainer:
 				dependenciesNotFoundComplainer.concatenate(complainer)
 			default:
-				return err
+				if container.stopAt(err) {
+					return dependenciesNotFoundComplainer.with(err)
+				}
+				problems.insert(err)
 			}
 		}
 	}
 
-	// If we had any missing dependencies, then return an error.
-	if 0 < dependenciesNotFoundComplainer.len() {
-		return dependenciesNotFoundComplainer
-	}
-
-	// Return (no errors).
-	return nil
+	// If we had any problems, then return an error.
+	return dependenciesNotFoundComplainer.with(problems.err())
 }
 
 
-func (container *internalContainer) injectMap(thing interface{}) error {
+func (container *internalContainer) injectMap(ctx context.Context, thing interface{}, path string) error {
 
 	// Initialize.
 	dependenciesNotFoundComplainer := newDependenciesNotFoundComplainer().(*internalDependenciesNotFoundComplainer)
+	problems := newInjectionComplainer().(*internalInjectionComplainer)
 
 	// Reflection!
 	value := reflect.ValueOf(thing)
 
-	// Go through each item in the map, and inject each of them.
-	keys := value.MapKeys()
+	// Go through each item in the map (in sorted key order), and inject each of them.
+	keys := sortedMapKeys(value)
 	for _,key := range keys {
 		element := value.MapIndex(key)
 
-		if err := container.Inject(element.Interface()); nil != err {
+		if err := container.injectPath(ctx, element.Interface(), fmt.Sprintf("%s[%#v]", path, key.Interface())); nil != err {
 			switch complainer := err.(type) {
 			case DependenciesNotFoundComplainer:
 				dependenciesNotFoundComplainer.concatenate(complainer)
 			default:
-				return err
+				if container.stopAt(err) {
+					return dependenciesNotFoundComplainer.with(err)
+				}
+				problems.insert(err)
 			}
 		}
 	}
 
-	// If we had any missing dependencies, then return an error.
-	if 0 < dependenciesNotFoundComplainer.len() {
-		return dependenciesNotFoundComplainer
-	}
-
-	// Return (no errors).
-	return nil
+	// If we had any problems, then return an error.
+	return dependenciesNotFoundComplainer.with(problems.err())
 }
@@ -0,0 +1,85 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+)
+
+
+func TestAlias(t *testing.T) {
+
+	container := New()
+
+	var count int
+	container.RegisterProvider("primary-db", func(ctx context.Context) (interface{}, error) {
+		count++
+		return &count, nil
+	})
+
+	if err := container.Alias("db", "primary-db"); nil != err {
+		t.Errorf("Received an error when aliasing: (%T) %v.", err, err)
+		return
+	}
+	if err := container.Alias("database", "db"); nil != err {
+		t.Errorf("Received an error when aliasing: (%T) %v.", err, err)
+		return
+	}
+
+	type Thing struct {
+		PrimaryDB *int `inject:"primary-db"`
+		DB        *int `inject:"db"`
+		Database  *int `inject:"database"`
+	}
+
+	thing := new(Thing)
+	if err := container.Inject(thing); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+
+	if thing.PrimaryDB != thing.DB || thing.DB != thing.Database {
+		t.Errorf("Expected all the names to resolve to the same instance, but they didn't: %p %p %p", thing.PrimaryDB, thing.DB, thing.Database)
+		return
+	}
+
+	if expected, actual := 1, count; expected != actual {
+		t.Errorf("Expected the provider to have been called %d times, but actually was called %d times.", expected, actual)
+		return
+	}
+}
+
+
+func TestAliasErrors(t *testing.T) {
+
+	container := New()
+	container.Register("apple", 1)
+	container.Register("banana", 2)
+	container.Alias("cherry", "apple")
+
+	tests := []struct{
+		Alias    string
+		Target   string
+		Expected error
+	}{
+		{Alias:"date",   Target:"not-there", Expected:ErrNotFound},
+		{Alias:"banana", Target:"apple",     Expected:ErrAlreadyRegistered},
+		{Alias:"cherry", Target:"banana",    Expected:ErrAlreadyRegistered},
+		{Alias:"apple",  Target:"cherry",    Expected:ErrAlreadyRegistered},
+		{Alias:"date",   Target:"date",      Expected:ErrNotFound},
+	}
+
+	for testNumber, test := range tests {
+		if err := container.Alias(test.Alias, test.Target); !errors.Is(err, test.Expected) {
+			t.Errorf("For test #%d, expected %v, but actually got: (%T) %v.", testNumber, test.Expected, err, err)
+			continue
+		}
+	}
+
+	if err := container.Register("cherry", 3); !errors.Is(err, ErrAlreadyRegistered) {
+		t.Errorf("Expected registering an alias name to fail, but actually got: (%T) %v.", err, err)
+		return
+	}
+}
@@ -0,0 +1,163 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+	"fmt"
+)
+
+
+type closer_TestClose struct {
+	name  string
+	calls *[]string
+	err   error
+}
+
+func (c *closer_TestClose) BeforeDestroy() error {
+	*c.calls = append(*c.calls, "before destroy "+c.name)
+	return nil
+}
+
+func (c *closer_TestClose) Close() error {
+	*c.calls = append(*c.calls, "close "+c.name)
+	return c.err
+}
+
+
+func TestClose(t *testing.T) {
+
+	var calls []string
+
+	container := New()
+	container.Register("config", &closer_TestClose{name:"config", calls:&calls})
+	container.RegisterProvider("db", func(ctx context.Context) (interface{}, error) {
+		return &closer_TestClose{name:"db", calls:&calls, err:errors.New("db close failed")}, nil
+	})
+	container.RegisterProvider("repository", func(ctx context.Context) (interface{}, error) {
+		if _, err := container.GetContext(ctx, "db"); nil != err {
+			return nil, err
+		}
+		return &closer_TestClose{name:"repository", calls:&calls}, nil
+	})
+	container.RegisterProvider("unused", func(ctx context.Context) (interface{}, error) {
+		return &closer_TestClose{name:"unused", calls:&calls}, nil
+	})
+	container.RegisterScoped("unit-of-work", func(ctx context.Context) (interface{}, error) {
+		return &closer_TestClose{name:"unit-of-work", calls:&calls}, nil
+	})
+	container.RegisterTransient("request-id", func(ctx context.Context) (interface{}, error) {
+		return &closer_TestClose{name:"request-id", calls:&calls}, nil
+	})
+
+	for _, name := range []string{"config", "repository", "unit-of-work", "request-id"} {
+		if _, err := container.Get(name); nil != err {
+			t.Errorf("Received an error when getting %q: (%T) %v.", name, err, err)
+			return
+		}
+	}
+
+	err := container.Close()
+	if nil == err || "db close failed" != err.Error() {
+		t.Errorf("Expected the error from closing the db, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	// "db" was constructed (while "repository" was being constructed) before "repository" was,
+	// so it is closed after it.
+	if expected, actual := "[before destroy unit-of-work close unit-of-work before destroy repository close repository before destroy db close db]", fmt.Sprint(calls); expected != actual {
+		t.Errorf("Expected calls %s, but actually got %s.", expected, actual)
+		return
+	}
+}
+
+
+func TestCloseWhileProviding(t *testing.T) {
+
+	var calls []string
+
+	started := make(chan struct{})
+	proceed := make(chan struct{})
+
+	container := New()
+	container.RegisterScoped("unit-of-work", func(ctx context.Context) (interface{}, error) {
+		close(started)
+		<-proceed
+		return &closer_TestClose{name:"unit-of-work", calls:&calls}, nil
+	})
+
+	scope := container.NewScope()
+
+	done := make(chan error)
+	go func() {
+		_, err := scope.Get("unit-of-work")
+		done <- err
+	}()
+
+	<-started
+	if err := scope.Close(); nil != err {
+		t.Errorf("Received an error when closing: (%T) %v.", err, err)
+		return
+	}
+	close(proceed)
+
+	if err := <-done; nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+
+	// What was still being constructed when the scope was closed gets closed once it is constructed.
+	if expected, actual := "[before destroy unit-of-work close unit-of-work]", fmt.Sprint(calls); expected != actual {
+		t.Errorf("Expected calls %s, but actually got %s.", expected, actual)
+		return
+	}
+}
+
+
+func TestCloseAfterRestore(t *testing.T) {
+
+	var calls []string
+	var constructed int
+
+	container := New()
+	container.RegisterProvider("db", func(ctx context.Context) (interface{}, error) {
+		constructed++
+		return &closer_TestClose{name:fmt.Sprintf("db#%d", constructed), calls:&calls}, nil
+	})
+
+	snapshot := container.Snapshot()
+
+	first, err := container.Get("db")
+	if nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+
+	if err := container.Restore(snapshot); nil != err {
+		t.Errorf("Received an error when restoring: (%T) %v.", err, err)
+		return
+	}
+
+	// What was constructed since the snapshot is kept, rather than constructed again.
+	second, err := container.Get("db")
+	if nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+	if first != second {
+		t.Errorf("Expected the same db after restoring, but actually got a different one.")
+		return
+	}
+
+	if err := container.Close(); nil != err {
+		t.Errorf("Received an error when closing: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := "[before destroy db#1 close db#1]", fmt.Sprint(calls); expected != actual {
+		t.Errorf("Expected calls %s, but actually got %s.", expected, actual)
+		return
+	}
+}
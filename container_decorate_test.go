@@ -0,0 +1,239 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+	"fmt"
+)
+
+
+func TestDecorate(t *testing.T) {
+
+	appender := func(suffix string) func(interface{}) (interface{}, error) {
+		return func(old interface{}) (interface{}, error) {
+			return fmt.Sprintf("%v%s", old, suffix), nil
+		}
+	}
+
+	container := New()
+
+	container.Register("value", "apple")
+	container.RegisterProvider("provided", func(ctx context.Context) (interface{}, error) {
+		return "banana", nil
+	})
+	container.RegisterScoped("scoped", func(ctx context.Context) (interface{}, error) {
+		return "cherry", nil
+	})
+
+	for _, name := range []string{"value", "provided", "scoped"} {
+		if err := container.Decorate(name, appender("-1")); nil != err {
+			t.Errorf("Received an error when decorating %q: (%T) %v.", name, err, err)
+			return
+		}
+		if err := container.Decorate(name, appender("-2")); nil != err {
+			t.Errorf("Received an error when decorating %q: (%T) %v.", name, err, err)
+			return
+		}
+	}
+
+	type Thing struct {
+		Value    string `inject:"value"`
+		Provided string `inject:"provided"`
+		Scoped   string `inject:"scoped"`
+	}
+
+	thing := new(Thing)
+	if err := container.NewScope().Inject(thing); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := "apple-1-2", thing.Value; expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+	if expected, actual := "banana-1-2", thing.Provided; expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+	if expected, actual := "cherry-1-2", thing.Scoped; expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	// Decorating something that has already been provided applies right away.
+	if err := container.Decorate("provided", appender("-3")); nil != err {
+		t.Errorf("Received an error when decorating: (%T) %v.", err, err)
+		return
+	}
+	if dependency, _ := container.Get("provided"); "banana-1-2-3" != dependency {
+		t.Errorf("Expected %q, but actually got %v.", "banana-1-2-3", dependency)
+		return
+	}
+}
+
+
+func TestDecorateErrors(t *testing.T) {
+
+	container := New()
+
+	if err := container.Decorate("not-there", nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a DependenciesNotFoundComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	cause := errors.New("apple banana cherry")
+
+	container.Register("value", "apple")
+	err := container.Decorate("value", func(old interface{}) (interface{}, error) {
+		return nil, cause
+	})
+	if _, ok := err.(ProblemProvidingDependencyComplainer); !ok {
+		t.Errorf("Expected a ProblemProvidingDependencyComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("Expected errors.Is(err, cause) to be true, but it wasn't.")
+		return
+	}
+}
+
+
+func TestDecorateScope(t *testing.T) {
+
+	appender := func(suffix string) func(interface{}) (interface{}, error) {
+		return func(old interface{}) (interface{}, error) {
+			return fmt.Sprintf("%v%s", old, suffix), nil
+		}
+	}
+
+	container := New()
+	container.Register("apple", "APPLE")
+	container.Alias("fruit", "apple")
+	container.RegisterProvider("banana", func(ctx context.Context) (interface{}, error) {
+		return "BANANA", nil
+	})
+	container.RegisterScoped("cherry", func(ctx context.Context) (interface{}, error) {
+		return "CHERRY", nil
+	})
+	container.Decorate("apple", appender("-app"))
+
+	scope := container.NewScope()
+
+	for _, name := range []string{"fruit", "banana", "cherry"} {
+		if err := scope.Decorate(name, appender("-scope")); nil != err {
+			t.Errorf("Received an error when decorating %q: (%T) %v.", name, err, err)
+			return
+		}
+	}
+
+	child := scope.NewScope()
+	if err := child.Decorate("apple", appender("-child")); nil != err {
+		t.Errorf("Received an error when decorating: (%T) %v.", err, err)
+		return
+	}
+
+	tests := []struct{
+		Container Container
+		Name      string
+		Expected  string
+	}{
+		{Container:scope,     Name:"apple",  Expected:"APPLE-app-scope"},
+		{Container:scope,     Name:"fruit",  Expected:"APPLE-app-scope"},
+		{Container:scope,     Name:"banana", Expected:"BANANA-scope"},
+		{Container:scope,     Name:"cherry", Expected:"CHERRY-scope"},
+		{Container:child,     Name:"apple",  Expected:"APPLE-app-scope-child"},
+		{Container:child,     Name:"cherry", Expected:"CHERRY-scope"},
+		{Container:container, Name:"apple",  Expected:"APPLE-app"},
+		{Container:container, Name:"banana", Expected:"BANANA"},
+		{Container:container, Name:"cherry", Expected:"CHERRY"},
+	}
+
+	for testNumber, test := range tests {
+		dependency, err := test.Container.Get(test.Name)
+		if nil != err {
+			t.Errorf("For test #%d, received an error when getting %q: (%T) %v.", testNumber, test.Name, err, err)
+			continue
+		}
+
+		if expected, actual := test.Expected, dependency; expected != actual {
+			t.Errorf("For test #%d, expected %q, but actually got %q.", testNumber, expected, actual)
+			continue
+		}
+	}
+
+	if err := scope.Close(); nil != err {
+		t.Errorf("Received an error when closing: (%T) %v.", err, err)
+		return
+	}
+
+	if dependency, err := container.Get("apple"); nil != err || "APPLE-app" != dependency {
+		t.Errorf("Expected %q after the scope was closed, but actually got: %v, (%T) %v.", "APPLE-app", dependency, err, err)
+		return
+	}
+	if dependency, err := container.NewScope().Get("banana"); nil != err || "BANANA" != dependency {
+		t.Errorf("Expected %q from another scope, but actually got: %v, (%T) %v.", "BANANA", dependency, err, err)
+		return
+	}
+}
+
+
+func TestDecorateScopeOfSealed(t *testing.T) {
+
+	decorator := func(old interface{}) (interface{}, error) {
+		return old.(int) * 10, nil
+	}
+
+	container := New()
+	container.Register("apple", 1)
+	container.RegisterProvider("banana", func(ctx context.Context) (interface{}, error) {
+		return 2, nil
+	})
+	container.Seal()
+
+	if err := container.Decorate("apple", decorator); !errors.Is(err, ErrSealed) {
+		t.Errorf("Expected a SealedComplainer when decorating the sealed container, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	scope := container.NewScope()
+	for _, name := range []string{"apple", "banana"} {
+		if err := scope.Decorate(name, decorator); nil != err {
+			t.Errorf("Received an error when decorating %q through the scope: (%T) %v.", name, err, err)
+			return
+		}
+	}
+
+	tests := []struct{
+		Container Container
+		Name      string
+		Expected  int
+	}{
+		{Container:scope,     Name:"apple",  Expected:10},
+		{Container:scope,     Name:"banana", Expected:20},
+		{Container:container, Name:"apple",  Expected:1},
+		{Container:container, Name:"banana", Expected:2},
+	}
+
+	for testNumber, test := range tests {
+		dependency, err := test.Container.Get(test.Name)
+		if nil != err {
+			t.Errorf("For test #%d, received an error when getting %q: (%T) %v.", testNumber, test.Name, err, err)
+			continue
+		}
+
+		if expected, actual := test.Expected, dependency; expected != actual {
+			t.Errorf("For test #%d, expected %v, but actually got %v.", testNumber, expected, actual)
+			continue
+		}
+	}
+
+	scope.Seal()
+	if err := scope.Decorate("apple", decorator); !errors.Is(err, ErrSealed) {
+		t.Errorf("Expected a SealedComplainer when decorating through a sealed scope, but actually got: (%T) %v.", err, err)
+		return
+	}
+}
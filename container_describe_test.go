@@ -0,0 +1,104 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+	"strings"
+)
+
+
+func TestHasAndNames(t *testing.T) {
+
+	container := New()
+
+	container.Register("cherry", 3)
+	container.Register("apple", 1)
+	container.Alias("banana", "apple")
+
+	for _, name := range []string{"apple", "banana", "cherry"} {
+		if !container.Has(name) {
+			t.Errorf("Expected Has(%q) to be true, but it wasn't.", name)
+			return
+		}
+	}
+	if container.Has("date") {
+		t.Errorf("Expected Has(%q) to be false, but it wasn't.", "date")
+		return
+	}
+
+	names := container.Names()
+	if expected, actual := "apple banana cherry", strings.Join(names, " "); expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+}
+
+
+func TestDescribe(t *testing.T) {
+
+	container := New()
+
+	container.RegisterProvider("primary-db", func(ctx context.Context) (interface{}, error) {
+		return new(int), nil
+	})
+	container.Alias("db", "primary-db")
+	container.Alias("database", "db")
+
+	description, err := container.Describe("primary-db")
+	if nil != err {
+		t.Errorf("Received an error when describing: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := LifetimeLazySingleton, description.Lifetime; expected != actual {
+		t.Errorf("Expected lifetime %v, but actually got %v.", expected, actual)
+		return
+	}
+	if expected, actual := "", description.Type; expected != actual {
+		t.Errorf("Expected type %q, but actually got %q.", expected, actual)
+		return
+	}
+	if expected, actual := "database db", strings.Join(description.Aliases, " "); expected != actual {
+		t.Errorf("Expected aliases %q, but actually got %q.", expected, actual)
+		return
+	}
+	if !strings.Contains(description.RegisteredAt, "container_describe_test.go:") {
+		t.Errorf("Expected the registration site to be in this file, but actually was %q.", description.RegisteredAt)
+		return
+	}
+
+	type Thing struct {
+		DB *int `inject:"db"`
+	}
+	if err := container.Inject(new(Thing)); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+
+	description, err = container.Describe("db")
+	if nil != err {
+		t.Errorf("Received an error when describing: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := "primary-db", description.AliasOf; expected != actual {
+		t.Errorf("Expected alias of %q, but actually got %q.", expected, actual)
+		return
+	}
+	if expected, actual := "*int", description.Type; expected != actual {
+		t.Errorf("Expected type %q, but actually got %q.", expected, actual)
+		return
+	}
+	if expected, actual := "*container.Thing.DB", strings.Join(description.Dependents, " "); expected != actual {
+		t.Errorf("Expected dependents %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	if _, err := container.Describe("not-there"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a DependenciesNotFoundComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+}
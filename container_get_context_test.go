@@ -0,0 +1,227 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+
+func TestGetContext(t *testing.T) {
+
+	type ctxKey struct{}
+
+	container := New()
+
+	container.RegisterProvider("request-id", func(ctx context.Context) (interface{}, error) {
+		return ctx.Value(ctxKey{}), nil
+	})
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "abc123")
+
+	dependency, err := container.GetContext(ctx, "request-id")
+	if nil != err {
+		t.Errorf("Received an error when trying to get: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := "abc123", dependency; expected != actual {
+		t.Errorf("Expected %q, but actually got %v.", expected, actual)
+		return
+	}
+}
+
+
+func TestGetContextCanceled(t *testing.T) {
+
+	container := New()
+
+	var calls int
+	container.RegisterProvider("db", func(ctx context.Context) (interface{}, error) {
+		calls++
+		return "database", nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := container.GetContext(ctx, "db")
+
+	complainer, ok := err.(CanceledComplainer)
+	if !ok {
+		t.Errorf("Expected a CanceledComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := "db", complainer.DependencyName(); expected != actual {
+		t.Errorf("Expected dependency name %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected errors.Is(err, context.Canceled) to be true, but it wasn't.")
+		return
+	}
+
+	if !errors.Is(err, ErrCanceled) {
+		t.Errorf("Expected errors.Is(err, ErrCanceled) to be true, but it wasn't.")
+		return
+	}
+
+	if expected, actual := 0, calls; expected != actual {
+		t.Errorf("Expected the provider to have been called %d times, but actually was called %d times.", expected, actual)
+		return
+	}
+}
+
+
+func TestGetContextDeadlineExceededInProvider(t *testing.T) {
+
+	container := New()
+
+	container.RegisterProvider("slow", func(ctx context.Context) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1)
+	defer cancel()
+
+	_, err := container.GetContext(ctx, "slow")
+
+	if _, ok := err.(CanceledComplainer); !ok {
+		t.Errorf("Expected a CanceledComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected errors.Is(err, context.DeadlineExceeded) to be true, but it wasn't.")
+		return
+	}
+}
+
+
+func TestInjectContextCanceled(t *testing.T) {
+
+	type Thing struct {
+		Fruit string `inject:"fruit"`
+	}
+
+	container := New(CollectAllErrors())
+	container.Register("fruit", "apple")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	things := []*Thing{new(Thing), new(Thing)}
+
+	err := container.InjectContext(ctx, things)
+
+	if _, ok := err.(CanceledComplainer); !ok {
+		t.Errorf("Expected a CanceledComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := "", things[0].Fruit; expected != actual {
+		t.Errorf("Expected nothing to have been injected, but actually got %q.", actual)
+		return
+	}
+}
+
+
+func TestGetContextCycle(t *testing.T) {
+
+	container := New()
+
+	container.RegisterProvider("apple", func(ctx context.Context) (interface{}, error) {
+		return container.GetContext(ctx, "banana")
+	})
+	container.RegisterProvider("banana", func(ctx context.Context) (interface{}, error) {
+		return container.GetContext(ctx, "apple")
+	})
+	container.RegisterTransient("cherry", func(ctx context.Context) (interface{}, error) {
+		return container.GetContext(ctx, "cherry")
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	_, err := container.GetContext(ctx, "apple")
+	if !errors.Is(err, ErrCycle) {
+		t.Errorf("Expected a CycleComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	var complainer CycleComplainer
+	if !errors.As(err, &complainer) {
+		t.Errorf("Expected a CycleComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+	if expected, actual := "[apple banana apple]", fmt.Sprint(complainer.Cycle()); expected != actual {
+		t.Errorf("Expected cycle %s, but actually got %s.", expected, actual)
+		return
+	}
+	if expected, actual := `Dependency cycle: "apple" -> "banana" -> "apple"`, complainer.Error(); expected != actual {
+		t.Errorf("Expected error message %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	if _, err := container.GetContext(ctx, "cherry"); !errors.Is(err, ErrCycle) {
+		t.Errorf("Expected a CycleComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+}
+
+
+func TestGetContextWhileProviding(t *testing.T) {
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	container := New()
+
+	container.RegisterProvider("slow", func(ctx context.Context) (interface{}, error) {
+		close(started)
+		<-release
+		return "done", nil
+	})
+
+	results := make(chan interface{})
+	go func() {
+		dependency, _ := container.Get("slow")
+		results <- dependency
+	}()
+
+	<-started
+
+	// Something else that needs the dependency, while it is being provided, waits
+	// only as long as its own context.Context lets it.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := container.GetContext(ctx, "slow"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a CanceledComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	close(release)
+
+	if expected, actual := "done", <-results; expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	// And once it has been provided, it is just there.
+	if dependency, err := container.GetContext(ctx, "slow"); nil == err || nil != dependency {
+		t.Errorf("Expected a CanceledComplainer (since the context.Context is done), but actually got: %v, (%T) %v.", dependency, err, err)
+		return
+	}
+	if dependency, err := container.Get("slow"); nil != err || "done" != dependency {
+		t.Errorf("Expected %q, but actually got: %v, (%T) %v.", "done", dependency, err, err)
+		return
+	}
+}
@@ -108,7 +108,7 @@ func TestGet(t *testing.T) {
 	for testNumber,test := range tests {
 		thing, err := container.Get(test.Name)
 		if nil != err {
-			t.Errorf("For test #%d, received an error when trying to get something from name %q. Error: (%T) %v.", testNumber, err, err)
+			t.Errorf("For test #%d, received an error when trying to get something from name %q. Error: (%T) %v.", testNumber, test.Name, err, err)
 			return
 		}
 
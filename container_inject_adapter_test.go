@@ -0,0 +1,184 @@
+package container
+
+
+import (
+	"testing"
+
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+
+type event_TestInjectAdapter string
+
+type eventChan_TestInjectAdapter chan event_TestInjectAdapter
+
+type events_TestInjectAdapter <-chan event_TestInjectAdapter
+
+type handlerFunc_TestInjectAdapter func(event_TestInjectAdapter) error
+
+type handler_TestInjectAdapter struct {
+	handled []string
+}
+
+func (handler *handler_TestInjectAdapter) Handle(event event_TestInjectAdapter) error {
+	if "" == event {
+		return errors.New("empty event")
+	}
+
+	handler.handled = append(handler.handled, string(event))
+	return nil
+}
+
+func (handler *handler_TestInjectAdapter) HandleLoudly(event event_TestInjectAdapter) error {
+	return handler.Handle(event_TestInjectAdapter(strings.ToUpper(string(event))))
+}
+
+func (handler *handler_TestInjectAdapter) Count() int {
+	return len(handler.handled)
+}
+
+type counter_TestInjectAdapter interface {
+	Count() int
+}
+
+type thing_TestInjectAdapter struct {
+	Handle       handlerFunc_TestInjectAdapter          `inject:"handle"`
+	HandleLoudly func(event_TestInjectAdapter) error    `inject:"handler,method=HandleLoudly"`
+	Count        func() int                             `inject:"counter,method=Count"`
+	Format       func(string, ...interface{}) string    `inject:"format"`
+	Stringify    func(int) interface{}                  `inject:"stringify"`
+	Events       events_TestInjectAdapter               `inject:"events"`
+	Sink         chan<- event_TestInjectAdapter         `inject:"events"`
+}
+
+
+func TestInjectAdapter(t *testing.T) {
+
+	var handler handler_TestInjectAdapter
+	events := make(eventChan_TestInjectAdapter, 1)
+
+	container := New()
+	container.Register("handle", handler.Handle)
+	container.Register("handler", &handler)
+	container.Register("counter", counter_TestInjectAdapter(&handler))
+	container.Register("format", fmt.Sprintf)
+	container.Register("stringify", func(n int) string { return fmt.Sprint(n) })
+	container.Register("events", events)
+
+	var thing thing_TestInjectAdapter
+	if err := container.Inject(&thing); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+
+	if err := thing.Handle("apple"); nil != err {
+		t.Errorf("Received an error when calling Handle: (%T) %v.", err, err)
+		return
+	}
+	if err := thing.Handle(""); nil == err || "empty event" != err.Error() {
+		t.Errorf("Expected the error returned by the method, but actually got: (%T) %v.", err, err)
+		return
+	}
+	if err := thing.HandleLoudly("banana"); nil != err {
+		t.Errorf("Received an error when calling HandleLoudly: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := "[apple BANANA]", fmt.Sprint(handler.handled); expected != actual {
+		t.Errorf("Expected handled %s, but actually got %s.", expected, actual)
+		return
+	}
+	if expected, actual := 2, thing.Count(); expected != actual {
+		t.Errorf("Expected count %d, but actually got %d.", expected, actual)
+		return
+	}
+	if expected, actual := "cherry-3", thing.Format("%s-%d", "cherry", 3); expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+	if expected, actual := interface{}("4"), thing.Stringify(4); expected != actual {
+		t.Errorf("Expected %#v, but actually got %#v.", expected, actual)
+		return
+	}
+
+	thing.Sink <- "date"
+	if expected, actual := event_TestInjectAdapter("date"), <-thing.Events; expected != actual {
+		t.Errorf("Expected event %q, but actually got %q.", expected, actual)
+		return
+	}
+}
+
+
+func TestInjectAdapterWrongType(t *testing.T) {
+
+	var handler handler_TestInjectAdapter
+	receiveOnly := make(chan event_TestInjectAdapter)
+
+	tests := []struct{
+		Dependency interface{}
+		Thing      interface{}
+	}{
+		// More than one method fits, and which one is not given.
+		{
+			Dependency: &handler,
+			Thing: &struct{
+				Handle func(event_TestInjectAdapter) error `inject:"dependency"`
+			}{},
+		},
+		// Only one method fits, but it is still not given.
+		{
+			Dependency: counter_TestInjectAdapter(&handler),
+			Thing: &struct{
+				Count func() int `inject:"dependency"`
+			}{},
+		},
+		{
+			Dependency: log.New(ioutil.Discard, "", 0),
+			Thing: &struct{
+				Prefix func(string) `inject:"dependency"`
+			}{},
+		},
+		// No such method.
+		{
+			Dependency: &handler,
+			Thing: &struct{
+				Handle func(event_TestInjectAdapter) error `inject:"dependency,method=Missing"`
+			}{},
+		},
+		// The parameters do not fit.
+		{
+			Dependency: func(n int) error { return nil },
+			Thing: &struct{
+				Handle func(event_TestInjectAdapter) error `inject:"dependency"`
+			}{},
+		},
+		// A receive-only channel cannot be sent on.
+		{
+			Dependency: (<-chan event_TestInjectAdapter)(receiveOnly),
+			Thing: &struct{
+				Sink chan<- event_TestInjectAdapter `inject:"dependency"`
+			}{},
+		},
+		// The element types are different.
+		{
+			Dependency: make(chan string),
+			Thing: &struct{
+				Events <-chan event_TestInjectAdapter `inject:"dependency"`
+			}{},
+		},
+	}
+
+	for testNumber, test := range tests {
+		container := New()
+		container.Register("dependency", test.Dependency)
+
+		if err := container.Inject(test.Thing); !errors.Is(err, ErrWrongType) {
+			t.Errorf("For test #%d, expected a WrongTypeComplainer, but actually got: (%T) %v.", testNumber, err, err)
+			continue
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package container
+
+
+import (
+	"testing"
+
+	"errors"
+	"sort"
+)
+
+
+func TestInjectCollectAllErrors(t *testing.T) {
+
+	type Thing struct {
+		WrongType        int    `inject:"apple"`
+		AnotherWrongType int    `inject:"banana"`
+		Missing          string `inject:"cherry"`
+		Fine             string `inject:"apple"`
+	}
+
+	things := []*Thing{
+		new(Thing),
+		new(Thing),
+	}
+
+	container := New(CollectAllErrors())
+
+	container.Register("apple", "one")
+	container.Register("banana", "two")
+
+	err := container.Inject(things)
+	if nil == err {
+		t.Errorf("Expected Inject to return an error, but it didn't.")
+		return
+	}
+
+	complainer, ok := err.(InjectionComplainer)
+	if !ok {
+		t.Errorf("Expected the error to fit the InjectionComplainer interface, but it didn't. Error: (%T) %q", err, err)
+		return
+	}
+
+	var paths []string
+	var notFoundCount int
+	for _, e := range complainer.Errs() {
+		switch c := e.(type) {
+		case WrongTypeComplainer:
+			paths = append(paths, c.Path())
+		case DependenciesNotFoundComplainer:
+			notFoundCount++
+			if expected, actual := []string{"[0].Missing", "[1].Missing"}, c.Paths("cherry"); len(expected) != len(actual) || expected[0] != actual[0] || expected[1] != actual[1] {
+				t.Errorf("Expected paths %q, but actually got %q.", expected, actual)
+				return
+			}
+		default:
+			t.Errorf("Unexpected error: (%T) %q", e, e)
+			return
+		}
+	}
+
+	if expected, actual := 1, notFoundCount; expected != actual {
+		t.Errorf("Expected %d DependenciesNotFoundComplainer, but actually got %d.", expected, actual)
+		return
+	}
+
+	sort.Strings(paths)
+	expectedPaths := []string{"[0].AnotherWrongType", "[0].WrongType", "[1].AnotherWrongType", "[1].WrongType"}
+	if expected, actual := len(expectedPaths), len(paths); expected != actual {
+		t.Errorf("Expected %d wrong types, but actually got %d: %q", expected, actual, paths)
+		return
+	}
+	for i := range expectedPaths {
+		if expected, actual := expectedPaths[i], paths[i]; expected != actual {
+			t.Errorf("For wrong type #%d, expected path %q, but actually got %q.", i, expected, actual)
+			return
+		}
+	}
+
+	for i, thing := range things {
+		if expected, actual := "one", thing.Fine; expected != actual {
+			t.Errorf("For thing #%d, expected Fine to have been injected with %q, but actually was %q.", i, expected, actual)
+			return
+		}
+	}
+}
+
+
+func TestInjectCollectAllErrorsMap(t *testing.T) {
+
+	type Thing struct {
+		WrongType int `inject:"apple"`
+	}
+
+	things := map[string]*Thing{}
+	for _, key := range []string{"kiwi", "apple", "fig", "banana", "cherry", "date", "elderberry", "grape"} {
+		things[key] = new(Thing)
+	}
+
+	container := New(CollectAllErrors())
+
+	container.Register("apple", "one")
+
+	err := container.Inject(things)
+	if nil == err {
+		t.Errorf("Expected Inject to return an error, but it didn't.")
+		return
+	}
+
+	complainer, ok := err.(InjectionComplainer)
+	if !ok {
+		t.Errorf("Expected the error to fit the InjectionComplainer interface, but it didn't. Error: (%T) %q", err, err)
+		return
+	}
+
+	expectedPaths := []string{`["apple"].WrongType`, `["banana"].WrongType`, `["cherry"].WrongType`, `["date"].WrongType`, `["elderberry"].WrongType`, `["fig"].WrongType`, `["grape"].WrongType`, `["kiwi"].WrongType`}
+	errs := complainer.Errs()
+	if expected, actual := len(expectedPaths), len(errs); expected != actual {
+		t.Errorf("Expected %d errors, but actually got %d: %q", expected, actual, err)
+		return
+	}
+	for i, e := range errs {
+		wrongType, ok := e.(WrongTypeComplainer)
+		if !ok {
+			t.Errorf("For error #%d, expected a WrongTypeComplainer, but actually got: (%T) %q", i, e, e)
+			return
+		}
+		if expected, actual := expectedPaths[i], wrongType.Path(); expected != actual {
+			t.Errorf("For error #%d, expected path %q, but actually got %q.", i, expected, actual)
+			return
+		}
+	}
+
+	// The same map should always give the same error message.
+	for i:=0; i<50; i++ {
+		if expected, actual := err.Error(), container.Inject(things).Error(); expected != actual {
+			t.Errorf("For inject #%d, expected the error message to be the same as the first time, but it wasn't.\nEXPECTED: %s\nACTUAL:   %s", i, expected, actual)
+			return
+		}
+	}
+
+	// So should checking it first, with the Strict option.
+	err = New(Strict()).Inject(map[int]*unexported_TestCheck{10:new(unexported_TestCheck), 2:new(unexported_TestCheck), 1:new(unexported_TestCheck)})
+	complainer, ok = err.(InjectionComplainer)
+	if !ok {
+		t.Errorf("Expected the strict Inject to return an InjectionComplainer, but actually got: (%T) %q", err, err)
+		return
+	}
+	for i, e := range complainer.Errs() {
+		if expected, actual := []string{"[1].logger", "[2].logger", "[10].logger"}[i], e.(UnsettableFieldComplainer).Path(); expected != actual {
+			t.Errorf("For strict error #%d, expected path %q, but actually got %q.", i, expected, actual)
+			return
+		}
+	}
+}
+
+
+func TestInjectWithoutCollectAllErrors(t *testing.T) {
+
+	type Thing struct {
+		WrongType        int `inject:"apple"`
+		AnotherWrongType int `inject:"banana"`
+	}
+
+	container := New()
+
+	container.Register("apple", "one")
+	container.Register("banana", "two")
+
+	err := container.Inject(new(Thing))
+
+	if _, ok := err.(WrongTypeComplainer); !ok {
+		t.Errorf("Expected the error to fit the WrongTypeComplainer interface, but it didn't. Error: (%T) %q", err, err)
+		return
+	}
+
+	if !errors.Is(err, ErrWrongType) {
+		t.Errorf("Expected errors.Is(err, ErrWrongType) to be true, but it wasn't.")
+		return
+	}
+}
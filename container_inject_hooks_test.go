@@ -0,0 +1,132 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+	"fmt"
+)
+
+
+type thingDependencies_TestAfterInject struct {
+	Apple int `inject:"apple"`
+	calls *[]string
+}
+
+func (dependencies *thingDependencies_TestAfterInject) AfterInject() error {
+	*dependencies.calls = append(*dependencies.calls, fmt.Sprintf("dependencies %d", dependencies.Apple))
+	return nil
+}
+
+type thing_TestAfterInject struct {
+	dependencies thingDependencies_TestAfterInject
+	Banana       int `inject:"banana"`
+	calls        []string
+}
+
+func (thing *thing_TestAfterInject) Dependencies() interface{} {
+	thing.dependencies.calls = &thing.calls
+	return &thing.dependencies
+}
+
+func (thing *thing_TestAfterInject) AfterInject() error {
+	if 0 > thing.Banana {
+		return errors.New("negative banana")
+	}
+
+	thing.calls = append(thing.calls, fmt.Sprintf("thing %d", thing.Banana))
+	return nil
+}
+
+
+func TestAfterInject(t *testing.T) {
+
+	container := New()
+	container.Register("apple", 1)
+	container.Register("banana", 2)
+
+	var thing thing_TestAfterInject
+	if err := container.Inject(&thing); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := "[dependencies 1 thing 2]", fmt.Sprint(thing.calls); expected != actual {
+		t.Errorf("Expected calls %s, but actually got %s.", expected, actual)
+		return
+	}
+
+	negative := New()
+	negative.Register("apple", 1)
+	negative.Register("banana", -2)
+
+	things := []*thing_TestAfterInject{new(thing_TestAfterInject), new(thing_TestAfterInject)}
+
+	err := negative.Inject(things)
+	if !errors.Is(err, ErrAfterInject) {
+		t.Errorf("Expected an AfterInjectComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := "Problem after injecting dependencies into *container.thing_TestAfterInject at [0]: negative banana", err.Error(); expected != actual {
+		t.Errorf("Expected error message %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	missing := New()
+	missing.Register("banana", 2)
+
+	var other thing_TestAfterInject
+	if err := missing.Inject(&other); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a DependenciesNotFoundComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+	if 0 < len(other.calls) {
+		t.Errorf("Expected no AfterInject methods to be called, since injecting failed, but actually got calls %v.", other.calls)
+		return
+	}
+}
+
+
+type unitOfWork_TestBeforeDestroy struct {
+	calls *[]string
+}
+
+func (u *unitOfWork_TestBeforeDestroy) BeforeDestroy() error {
+	*u.calls = append(*u.calls, "before destroy")
+	return nil
+}
+
+func (u *unitOfWork_TestBeforeDestroy) Close() error {
+	*u.calls = append(*u.calls, "close")
+	return nil
+}
+
+
+func TestBeforeDestroy(t *testing.T) {
+
+	var calls []string
+
+	container := New()
+	container.RegisterScoped("unit-of-work", func(ctx context.Context) (interface{}, error) {
+		return &unitOfWork_TestBeforeDestroy{calls:&calls}, nil
+	})
+
+	scope := container.NewScope()
+	if _, err := scope.Get("unit-of-work"); nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+
+	if err := scope.Close(); nil != err {
+		t.Errorf("Received an error when closing: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := "[before destroy close]", fmt.Sprint(calls); expected != actual {
+		t.Errorf("Expected calls %s, but actually got %s.", expected, actual)
+		return
+	}
+}
@@ -0,0 +1,100 @@
+package container
+
+
+import (
+	"testing"
+
+	"errors"
+)
+
+
+type handlerDependencies_TestInjectPath struct {
+	Store  interface{} `inject:"store"`
+	Length int         `inject:"length"`
+}
+
+type handler_TestInjectPath struct {
+	dependencies handlerDependencies_TestInjectPath
+}
+
+func (h *handler_TestInjectPath) Dependencies() interface{} {
+	return &h.dependencies
+}
+
+
+func TestInjectPathNotFound(t *testing.T) {
+
+	handlers := map[string][]*handler_TestInjectPath{
+		"eu-west": []*handler_TestInjectPath{
+			new(handler_TestInjectPath),
+			new(handler_TestInjectPath),
+		},
+	}
+
+	container := New()
+	container.Register("length", 5)
+
+	err := container.Inject(handlers)
+	if nil == err {
+		t.Errorf("Expected Inject to return an error, but it didn't.")
+		return
+	}
+
+	complainer, ok := err.(DependenciesNotFoundComplainer)
+	if !ok {
+		t.Errorf("Expected the error to fit the DependenciesNotFoundComplainer interface, but it didn't. Error: (%T) %q", err, err)
+		return
+	}
+
+	paths := complainer.Paths("store")
+
+	if expected, actual := 2, len(paths); expected != actual {
+		t.Errorf("Expected %d paths, but actually got %d: %q", expected, actual, paths)
+		return
+	}
+
+	if expected, actual := `["eu-west"][0].Dependencies().Store`, paths[0]; expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	if expected, actual := `["eu-west"][1].Dependencies().Store`, paths[1]; expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	if expected, actual := `Dependencies not found: "store" required by *container.handlerDependencies_TestInjectPath.Store at ["eu-west"][0].Dependencies().Store, *container.handlerDependencies_TestInjectPath.Store at ["eu-west"][1].Dependencies().Store`, err.Error(); expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+}
+
+
+func TestInjectPathWrongType(t *testing.T) {
+
+	handlers := []*handler_TestInjectPath{
+		new(handler_TestInjectPath),
+	}
+
+	container := New()
+	container.Register("store", "apple-banana-cherry")
+	container.Register("length", "not an int")
+
+	err := container.Inject(handlers)
+
+	var complainer WrongTypeComplainer
+	if !errors.As(err, &complainer) {
+		t.Errorf("Expected the error to fit the WrongTypeComplainer interface, but it didn't. Error: (%T) %q", err, err)
+		return
+	}
+
+	if expected, actual := `[0].Dependencies().Length`, complainer.Path(); expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	if expected, actual := `Wrong type for dependency "length" at [0].Dependencies().Length`, complainer.Error(); expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+}
@@ -0,0 +1,196 @@
+package container
+
+
+import (
+	"testing"
+
+	"fmt"
+	"reflect"
+)
+
+
+type thing_BenchmarkInject struct {
+	F00 string `inject:"f00"`
+	F01 string `inject:"f01"`
+	F02 string `inject:"f02"`
+	F03 string `inject:"f03"`
+	F04 string `inject:"f04"`
+	F05 string `inject:"f05"`
+	F06 string `inject:"f06"`
+	F07 string `inject:"f07"`
+	F08 string `inject:"f08"`
+	F09 string `inject:"f09"`
+	F10 string `inject:"f10"`
+	F11 string `inject:"f11"`
+	F12 string `inject:"f12"`
+	F13 string `inject:"f13"`
+	F14 string `inject:"f14"`
+	F15 string `inject:"f15"`
+	F16 string `inject:"f16"`
+	F17 string `inject:"f17"`
+	F18 string `inject:"f18"`
+	F19 string `inject:"f19"`
+}
+
+
+// injectsPerOp_BenchmarkInject is how many times each op of the benchmarks injects.
+const injectsPerOp_BenchmarkInject = 10000
+
+
+func newContainer_BenchmarkInject(b *testing.B) Container {
+	container := New()
+
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("f%02d", i)
+
+		if err := container.Register(name, name); nil != err {
+			b.Fatalf("Received an error when registering: (%T) %v.", err, err)
+		}
+	}
+
+	return container
+}
+
+
+// BenchmarkInject injects into a struct with 20 fields, using the cached injectionPlan.
+func BenchmarkInject(b *testing.B) {
+
+	container := newContainer_BenchmarkInject(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		for i := 0; i < injectsPerOp_BenchmarkInject; i++ {
+			var thing thing_BenchmarkInject
+
+			if err := container.Inject(&thing); nil != err {
+				b.Fatalf("Received an error when injecting: (%T) %v.", err, err)
+			}
+		}
+	}
+}
+
+
+// BenchmarkInjectWithoutPlanCache is like BenchmarkInject, except that the cached injectionPlan is
+// thrown away before each inject. So each time, the Inject method works out (with reflection) the
+// struct's fields and parses their `inject` struct tags all over again, the way it did before there
+// were injection plans. It is what BenchmarkInject should be compared against. As in:
+//
+//	go test -run XXX -bench 'BenchmarkInject(WithoutPlanCache)?$' -benchmem -count 5
+//
+// What the injection plan mostly saves is allocations. (Working out the plan allocates the field
+// paths, the names of what required each field, and so on.) With a cached plan, injecting into a
+// struct whose dependencies are all registered allocates nothing at all. (See TestInjectAllocs.)
+func BenchmarkInjectWithoutPlanCache(b *testing.B) {
+
+	container := newContainer_BenchmarkInject(b)
+
+	typ := reflect.TypeOf(thing_BenchmarkInject{})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		for i := 0; i < injectsPerOp_BenchmarkInject; i++ {
+			var thing thing_BenchmarkInject
+
+			injectionPlans.Delete(typ)
+
+			if err := container.Inject(&thing); nil != err {
+				b.Fatalf("Received an error when injecting: (%T) %v.", err, err)
+			}
+		}
+	}
+}
+
+
+func TestInjectionPlan(t *testing.T) {
+
+	type Thing struct {
+		Apple   int    `inject:"apple"`
+		Banana  string
+		cherry  int    `inject:"cherry"`
+		Date    []int  `inject:"group:dates"`
+		Empty   int    `inject:""`
+	}
+
+	typ := reflect.TypeOf(Thing{})
+
+	plan := planFor(typ)
+
+	if plan != planFor(typ) {
+		t.Errorf("Expected the injection plan to be cached, but it wasn't.")
+		return
+	}
+
+	if expected, actual := 3, len(plan.fields); expected != actual {
+		t.Errorf("Expected %d planned fields, but actually got %d: %#v", expected, actual, plan.fields)
+		return
+	}
+
+	tests := []struct{
+		Index      int
+		Name       string
+		Exported   bool
+		Group      bool
+		RequiredBy string
+	}{
+		{Index:0, Name:"Apple",  Exported:true,  RequiredBy:"*container.Thing.Apple"},
+		{Index:2, Name:"cherry", Exported:false, RequiredBy:"*container.Thing.cherry"},
+		{Index:3, Name:"Date",   Exported:true,  Group:true, RequiredBy:"*container.Thing.Date"},
+	}
+
+	for testNumber, test := range tests {
+		field := plan.fields[testNumber]
+
+		if expected, actual := test.Index, field.index; expected != actual {
+			t.Errorf("For test #%d, expected index %d, but actually got %d.", testNumber, expected, actual)
+			continue
+		}
+		if expected, actual := test.Name, field.name; expected != actual {
+			t.Errorf("For test #%d, expected name %q, but actually got %q.", testNumber, expected, actual)
+			continue
+		}
+		if expected, actual := test.Exported, field.exported; expected != actual {
+			t.Errorf("For test #%d, expected exported %t, but actually got %t.", testNumber, expected, actual)
+			continue
+		}
+		if expected, actual := test.Group, field.tag.group; expected != actual {
+			t.Errorf("For test #%d, expected group %t, but actually got %t.", testNumber, expected, actual)
+			continue
+		}
+		if expected, actual := test.RequiredBy, field.requiredBy; expected != actual {
+			t.Errorf("For test #%d, expected required by %q, but actually got %q.", testNumber, expected, actual)
+			continue
+		}
+	}
+}
+
+
+func TestInjectAllocs(t *testing.T) {
+
+	container := New()
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("f%02d", i)
+
+		if err := container.Register(name, name); nil != err {
+			t.Errorf("Received an error when registering: (%T) %v.", err, err)
+			return
+		}
+	}
+
+	var thing thing_BenchmarkInject
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := container.Inject(&thing); nil != err {
+			t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		}
+	})
+
+	// Nothing should be allocated when there are no problems. (Not even complainers.)
+	if expected, actual := float64(0), allocs; expected != actual {
+		t.Errorf("Expected %v allocations per inject, but actually got %v.", expected, actual)
+		return
+	}
+}
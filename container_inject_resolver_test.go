@@ -0,0 +1,128 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+	"fmt"
+)
+
+
+type pluginLoader_TestInjectResolver struct {
+	Resolver Resolver `inject:"@container"`
+}
+
+func (loader *pluginLoader_TestInjectResolver) Load(names ...string) ([]interface{}, error) {
+	var loaded []interface{}
+
+	for _, name := range names {
+		dependency, err := loader.Resolver.Get(name)
+		if nil != err {
+			return nil, err
+		}
+
+		loaded = append(loaded, dependency)
+	}
+
+	return loaded, nil
+}
+
+
+func TestInjectResolver(t *testing.T) {
+
+	container := New()
+	container.Register("apple", 1)
+	container.Namespace("kitchen.").Register("banana", 2)
+	container.RegisterScoped("cherry", func(ctx context.Context) (interface{}, error) {
+		return new(int), nil
+	})
+
+	var loader pluginLoader_TestInjectResolver
+	if err := container.Inject(&loader); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+
+	if _, ok := loader.Resolver.(Container); ok {
+		t.Errorf("Expected the Resolver to not be a Container, but it was.")
+		return
+	}
+
+	loaded, err := loader.Load("apple", "kitchen.banana")
+	if nil != err {
+		t.Errorf("Received an error when loading: (%T) %v.", err, err)
+		return
+	}
+	if expected, actual := "[1 2]", fmt.Sprint(loaded); expected != actual {
+		t.Errorf("Expected loaded %s, but actually got %s.", expected, actual)
+		return
+	}
+
+	if _, err := loader.Load("date"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a DependenciesNotFoundComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	// A Resolver injected from a namespace resolves names within that namespace.
+	var kitchenLoader pluginLoader_TestInjectResolver
+	if err := container.Namespace("kitchen.").Inject(&kitchenLoader); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+	if !kitchenLoader.Resolver.Has("banana") {
+		t.Errorf("Expected the namespaced Resolver to have %q, but it didn't.", "banana")
+		return
+	}
+
+	// A Resolver injected from a scope resolves scoped dependencies from that scope.
+	scope := container.NewScope()
+	defer scope.Close()
+
+	var scopeLoader pluginLoader_TestInjectResolver
+	if err := scope.Inject(&scopeLoader); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+
+	first, err := scopeLoader.Resolver.Get("cherry")
+	if nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+	second, err := scope.Get("cherry")
+	if nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+	if first != second {
+		t.Errorf("Expected the Resolver to resolve from the scope, but it didn't.")
+		return
+	}
+
+	if err := container.Register("@container", 3); !errors.Is(err, ErrAlreadyRegistered) {
+		t.Errorf("Expected an AlreadyRegisteredComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	var wrong struct{
+		Resolver string `inject:"@container"`
+	}
+	if err := container.Inject(&wrong); !errors.Is(err, ErrWrongType) {
+		t.Errorf("Expected a WrongTypeComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	results, err := container.Invoke(func(resolver Resolver) (interface{}, error) {
+		return resolver.Get("apple")
+	})
+	if nil != err {
+		t.Errorf("Received an error when invoking: (%T) %v.", err, err)
+		return
+	}
+	if expected, actual := "[1]", fmt.Sprint(results); expected != actual {
+		t.Errorf("Expected results %s, but actually got %s.", expected, actual)
+		return
+	}
+}
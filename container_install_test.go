@@ -0,0 +1,114 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+	"fmt"
+)
+
+
+func TestInstall(t *testing.T) {
+
+	var order []string
+
+	db := NewModule("db", []string{"db"}, []string{"config"}, func(c Container) error {
+		order = append(order, "db")
+
+		config, err := c.Get("config")
+		if nil != err {
+			return err
+		}
+
+		return c.Register("db", "db for "+config.(string))
+	})
+
+	config := NewModule("config", []string{"config"}, nil, func(c Container) error {
+		order = append(order, "config")
+
+		return c.Register("config", "production")
+	})
+
+	cache := NewModule("cache", []string{"cache"}, []string{"logger"}, func(c Container) error {
+		order = append(order, "cache")
+
+		return c.RegisterProvider("cache", func(ctx context.Context) (interface{}, error) {
+			return "cache", nil
+		})
+	})
+
+	container := New()
+	container.Register("logger", "logger")
+
+	if err := container.Install(db, cache, config); nil != err {
+		t.Errorf("Received an error when installing: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := "[cache config db]", fmt.Sprint(order); expected != actual {
+		t.Errorf("Expected modules to be installed in order %s, but actually were installed in order %s.", expected, actual)
+		return
+	}
+
+	dependency, err := container.Get("db")
+	if nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+	if expected, actual := "db for production", dependency; expected != actual {
+		t.Errorf("Expected %q, but actually got %v.", expected, actual)
+		return
+	}
+}
+
+
+func TestInstallMissingRequirements(t *testing.T) {
+
+	registered := false
+
+	db := NewModule("db", []string{"db"}, []string{"config", "logger"}, func(c Container) error {
+		registered = true
+		return c.Register("db", "db")
+	})
+	queue := NewModule("queue", []string{"queue"}, []string{"config"}, func(c Container) error {
+		registered = true
+		return c.Register("queue", "queue")
+	})
+
+	container := New()
+	container.Register("logger", "logger")
+
+	err := container.Install(db, queue)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a DependenciesNotFoundComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := `Dependencies not found: "config" required by module db, module queue`, err.Error(); expected != actual {
+		t.Errorf("Expected error message %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	if registered {
+		t.Errorf("Expected nothing to be registered, but something was.")
+		return
+	}
+}
+
+
+func TestInstallDoesNotProvide(t *testing.T) {
+
+	lazy := NewModule("lazy", []string{"lazy"}, nil, func(c Container) error {
+		return nil
+	})
+
+	container := New()
+
+	if err := container.Install(lazy); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected an error, since the module did not register what it said it provides, but actually got: (%T) %v.", err, err)
+		return
+	}
+}
+
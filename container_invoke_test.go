@@ -0,0 +1,181 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+
+type service_TestInvoke struct {
+	logger *log.Logger
+	writer io.Writer
+	name   string
+	ctx    context.Context
+}
+
+func newService_TestInvoke(ctx context.Context, logger *log.Logger, writer io.Writer, name string) (*service_TestInvoke, error) {
+	if "" == name {
+		return nil, errors.New("no name")
+	}
+
+	service := service_TestInvoke{
+		logger:logger,
+		writer:writer,
+		name:name,
+		ctx:ctx,
+	}
+
+	return &service, nil
+}
+
+
+func TestConstruct(t *testing.T) {
+
+	logger := log.New(ioutil.Discard, "", 0)
+	var builder strings.Builder
+
+	container := New()
+	container.Register("logger", logger)
+	container.Register("writer", &builder)
+	container.Register("service-name", "billing")
+	container.Register("other-name", "")
+
+	ctx := context.WithValue(context.Background(), "apple", "banana")
+
+	dependency, err := container.ConstructContext(ctx, newService_TestInvoke, "", "", "", "service-name")
+	if nil != err {
+		t.Errorf("Received an error when constructing: (%T) %v.", err, err)
+		return
+	}
+
+	service, ok := dependency.(*service_TestInvoke)
+	if !ok {
+		t.Errorf("Expected a *service_TestInvoke, but actually got %T.", dependency)
+		return
+	}
+
+	if logger != service.logger || &builder != service.writer || "billing" != service.name || ctx != service.ctx {
+		t.Errorf("Unexpected service: %#v", service)
+		return
+	}
+
+	if _, err := container.Construct(newService_TestInvoke, "", "", "", "other-name"); nil == err || "no name" != err.Error() {
+		t.Errorf("Expected the constructor's error, but actually got: (%T) %v.", err, err)
+		return
+	}
+}
+
+
+func TestInvoke(t *testing.T) {
+
+	container := New()
+	container.Register("apple", 1)
+	container.Register("banana", "two")
+
+	results, err := container.Invoke(func(n int, s string) (string, int, error) {
+		return fmt.Sprintf("%s-%d", s, n), n+1, nil
+	})
+	if nil != err {
+		t.Errorf("Received an error when invoking: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := "[two-1 2]", fmt.Sprint(results); expected != actual {
+		t.Errorf("Expected results %s, but actually got %s.", expected, actual)
+		return
+	}
+}
+
+
+func TestInvokeErrors(t *testing.T) {
+
+	container := New()
+	container.Register("apple", 1)
+	container.Register("banana", 2)
+	container.Register("cherry", "three")
+	container.RegisterProvider("date", func(ctx context.Context) (interface{}, error) {
+		return 4.0, nil
+	})
+
+	tests := []struct{
+		Func     interface{}
+		Names    []string
+		Expected error
+	}{
+		{Func:func(n int) {},     Expected:ErrAmbiguousDependency},
+		{Func:func(f float64) {}, Expected:ErrAmbiguousDependency},
+		{Func:func(b bool) {},    Expected:ErrAmbiguousDependency},
+		{Func:func(s string) {},  Names:[]string{"apple"},  Expected:ErrWrongType},
+		{Func:func(s string) {},  Names:[]string{"eggplant"}, Expected:ErrNotFound},
+	}
+
+	for testNumber, test := range tests {
+		if _, err := container.Invoke(test.Func, test.Names...); !errors.Is(err, test.Expected) {
+			t.Errorf("For test #%d, expected %v, but actually got: (%T) %v.", testNumber, test.Expected, err, err)
+			continue
+		}
+	}
+
+	var complainer AmbiguousDependencyComplainer
+	if _, err := container.Invoke(func(n int) {}); !errors.As(err, &complainer) {
+		t.Errorf("Expected an AmbiguousDependencyComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+	if expected, actual := "[apple banana]", fmt.Sprint(complainer.Candidates()); expected != actual {
+		t.Errorf("Expected candidates %s, but actually got %s.", expected, actual)
+		return
+	}
+
+	var wrongType WrongTypeComplainer
+	if _, err := container.Invoke(func(s string) {}, "apple"); !errors.As(err, &wrongType) {
+		t.Errorf("Expected a WrongTypeComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+	if expected, actual := "", wrongType.Path(); expected != actual {
+		t.Errorf("Expected path %q (since it is not about a struct field), but actually got %q.", expected, actual)
+		return
+	}
+	if message := wrongType.Error(); !strings.HasPrefix(message, `Wrong type for dependency "apple" required by `) || !strings.HasSuffix(message, " parameter #1") {
+		t.Errorf("Expected the error message to say which parameter it is about, but it didn't: %q", message)
+		return
+	}
+
+	// The type of what a provider provides is not known until it is provided. And it is not
+	// looked at even then, so that what gets resolved does not depend on what has been provided
+	// so far.
+	for i:=0; i<2; i++ {
+		if _, err := container.Invoke(func(f float64) {}); !errors.As(err, &complainer) {
+			t.Errorf("Expected an AmbiguousDependencyComplainer, but actually got: (%T) %v.", err, err)
+			return
+		}
+		if expected, actual := "[date]", fmt.Sprint(complainer.Candidates()); expected != actual {
+			t.Errorf("Expected candidates %s, but actually got %s.", expected, actual)
+			return
+		}
+
+		container.Get("date")
+	}
+
+	if _, err := container.Invoke(func(f float64) {}, "date"); nil != err {
+		t.Errorf("Received an error when invoking: (%T) %v.", err, err)
+		return
+	}
+
+	if _, err := New().Invoke(func(f float64) {}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a DependenciesNotFoundComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	if _, err := container.Invoke(func(s string) {}, "cherry", "too-many"); nil == err {
+		t.Errorf("Expected an error when passing too many names, but did not receive one.")
+		return
+	}
+}
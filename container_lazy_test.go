@@ -0,0 +1,180 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+)
+
+
+type orders_TestLazy struct {
+	Customers Lazy[*customers_TestLazy] `inject:"customers"`
+}
+
+type customers_TestLazy struct {
+	Orders *orders_TestLazy `inject:"orders"`
+}
+
+
+func TestLazy(t *testing.T) {
+
+	var constructed int
+
+	container := New()
+	container.RegisterProvider("orders", func(ctx context.Context) (interface{}, error) {
+		constructed++
+		return container.NewContext(ctx, (*orders_TestLazy)(nil))
+	})
+	container.RegisterProvider("customers", func(ctx context.Context) (interface{}, error) {
+		constructed++
+		return container.NewContext(ctx, (*customers_TestLazy)(nil))
+	})
+
+	dependency, err := container.Get("orders")
+	if nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+	orders := dependency.(*orders_TestLazy)
+
+	if expected, actual := 1, constructed; expected != actual {
+		t.Errorf("Expected %d constructed, but actually got %d.", expected, actual)
+		return
+	}
+
+	customers, err := orders.Customers.Get()
+	if nil != err {
+		t.Errorf("Received an error when getting lazily: (%T) %v.", err, err)
+		return
+	}
+	if orders != customers.Orders {
+		t.Errorf("Expected the customers to have the same orders, but they didn't.")
+		return
+	}
+
+	again, err := orders.Customers.Get()
+	if nil != err {
+		t.Errorf("Received an error when getting lazily: (%T) %v.", err, err)
+		return
+	}
+	if customers != again {
+		t.Errorf("Expected the same customers the second time, but didn't get them.")
+		return
+	}
+
+	if expected, actual := 2, constructed; expected != actual {
+		t.Errorf("Expected %d constructed, but actually got %d.", expected, actual)
+		return
+	}
+}
+
+
+func TestLazyErrors(t *testing.T) {
+
+	var nada Lazy[int]
+	if _, err := nada.Get(); nil == err {
+		t.Errorf("Expected an error from a Lazy that was never injected into, but did not receive one.")
+		return
+	}
+
+	var calls int
+
+	container := New()
+	container.Register("apple", "one")
+	container.RegisterProvider("banana", func(ctx context.Context) (interface{}, error) {
+		calls++
+		return nil, errors.New("no banana")
+	})
+
+	var missing struct{
+		Cherry Lazy[int] `inject:"cherry"`
+	}
+	if err := container.Inject(&missing); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a DependenciesNotFoundComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	var thing struct{
+		Apple  Lazy[int]      `inject:"apple"`
+		Banana Lazy[string]   `inject:"banana"`
+		Routes Lazy[[]string] `inject:"group:routes"`
+	}
+	if err := container.Inject(&thing); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+
+	if routes, err := thing.Routes.Get(); nil != err || nil == routes || 0 != len(routes) {
+		t.Errorf("Expected an empty group, but actually got: %#v, (%T) %v.", routes, err, err)
+		return
+	}
+
+	if _, err := thing.Apple.Get(); !errors.Is(err, ErrWrongType) {
+		t.Errorf("Expected a WrongTypeComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	for i:=0; i<2; i++ {
+		if _, err := thing.Banana.Get(); !errors.Is(err, ErrProblemProvidingDependency) {
+			t.Errorf("Expected a ProblemProvidingDependencyComplainer, but actually got: (%T) %v.", err, err)
+			return
+		}
+	}
+	if expected, actual := 1, calls; expected != actual {
+		t.Errorf("Expected the provider to be called %d time, but it was actually called %d times.", expected, actual)
+		return
+	}
+}
+
+
+func TestLazyClosedScope(t *testing.T) {
+
+	var constructed int
+
+	container := New()
+	container.RegisterScoped("unit-of-work", func(ctx context.Context) (interface{}, error) {
+		constructed++
+		return "unit of work", nil
+	})
+
+	scope := container.NewScope()
+
+	var thing struct{
+		Lazily   Lazy[string]     `inject:"unit-of-work"`
+		Provided Provider[string] `inject:"unit-of-work"`
+	}
+	if err := scope.Inject(&thing); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+
+	if err := scope.Close(); nil != err {
+		t.Errorf("Received an error when closing: (%T) %v.", err, err)
+		return
+	}
+
+	if _, err := thing.Lazily.Get(); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected a ClosedComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+	if _, err := thing.Provided(); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected a ClosedComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+	if _, err := scope.NewScope().Get("unit-of-work"); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected a ClosedComplainer from a scope of the closed scope, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := 0, constructed; expected != actual {
+		t.Errorf("Expected %d constructed, but actually got %d.", expected, actual)
+		return
+	}
+
+	if _, err := container.Get("unit-of-work"); nil != err {
+		t.Errorf("Received an error when getting through the (not closed) parent: (%T) %v.", err, err)
+		return
+	}
+}
@@ -0,0 +1,136 @@
+package container
+
+
+import (
+	"testing"
+)
+
+
+func TestNamespace(t *testing.T) {
+
+	container := New()
+
+	db := container.Namespace("db.")
+
+	if err := db.Register("primary", "primary-database"); nil != err {
+		t.Errorf("Received an error when registering: (%T) %v.", err, err)
+		return
+	}
+	if err := db.Register("replica", "replica-database"); nil != err {
+		t.Errorf("Received an error when registering: (%T) %v.", err, err)
+		return
+	}
+	if err := db.Alias("main", "primary"); nil != err {
+		t.Errorf("Received an error when aliasing: (%T) %v.", err, err)
+		return
+	}
+	container.Register("logger", "the-logger")
+
+	if dependency, err := container.Get("db.primary"); nil != err || "primary-database" != dependency {
+		t.Errorf("Expected %q, but actually got %v (error: %v).", "primary-database", dependency, err)
+		return
+	}
+	if dependency, err := db.Get("main"); nil != err || "primary-database" != dependency {
+		t.Errorf("Expected %q, but actually got %v (error: %v).", "primary-database", dependency, err)
+		return
+	}
+	if _, err := db.Get("logger"); nil == err {
+		t.Errorf("Expected an error getting something outside of the namespace, but didn't get one.")
+		return
+	}
+
+	type Thing struct {
+		Primary string `inject:"primary"`
+	}
+
+	thing := new(Thing)
+	if err := db.Inject(thing); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+	if expected, actual := "primary-database", thing.Primary; expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+}
+
+
+func TestList(t *testing.T) {
+
+	container := New()
+
+	container.Register("db.replica", 2)
+	container.Register("db.primary", 1)
+	container.Register("logger", 3)
+	container.Alias("db.main", "db.primary")
+
+	scope := container.NewScope()
+	scope.Register("db.scoped", 4)
+
+	tests := []struct{
+		Container Container
+		Prefix    string
+		Expected  []string
+	}{
+		{Container:container, Prefix:"db.", Expected:[]string{"db.main", "db.primary", "db.replica"}},
+		{Container:container, Prefix:"",    Expected:[]string{"db.main", "db.primary", "db.replica", "logger"}},
+		{Container:container, Prefix:"x",   Expected:[]string{}},
+		{Container:scope,     Prefix:"db.", Expected:[]string{"db.main", "db.primary", "db.replica", "db.scoped"}},
+		{Container:container.Namespace("db."), Prefix:"", Expected:[]string{"main", "primary", "replica"}},
+	}
+
+	for testNumber, test := range tests {
+		actual := test.Container.List(test.Prefix)
+
+		if expected, actual := len(test.Expected), len(actual); expected != actual {
+			t.Errorf("For test #%d, expected %d names, but actually got %d: %q", testNumber, expected, actual, test.Container.List(test.Prefix))
+			continue
+		}
+		for i := range actual {
+			if expected, actual := test.Expected[i], actual[i]; expected != actual {
+				t.Errorf("For test #%d, name #%d, expected %q, but actually got %q.", testNumber, i, expected, actual)
+				continue
+			}
+		}
+	}
+}
+
+
+func TestInjectPrefix(t *testing.T) {
+
+	container := New()
+
+	container.Register("db.primary", 1)
+	container.Register("db.replica", 2)
+	container.Register("logger", 3)
+
+	type Thing struct {
+		DBs    map[string]int `inject:"prefix:db."`
+		Caches map[string]int `inject:"prefix:cache."`
+	}
+
+	thing := new(Thing)
+	if err := container.Inject(thing); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := 2, len(thing.DBs); expected != actual {
+		t.Errorf("Expected %d, but actually got %d: %v", expected, actual, thing.DBs)
+		return
+	}
+	if expected, actual := 1, thing.DBs["primary"]; expected != actual {
+		t.Errorf("Expected %d, but actually got %d.", expected, actual)
+		return
+	}
+	if expected, actual := 2, thing.DBs["replica"]; expected != actual {
+		t.Errorf("Expected %d, but actually got %d.", expected, actual)
+		return
+	}
+
+	// Nothing is registered with a name that begins with "cache." so that is an empty map.
+	if nil == thing.Caches || 0 != len(thing.Caches) {
+		t.Errorf("Expected an empty (non-nil) map, but actually got %#v.", thing.Caches)
+		return
+	}
+}
@@ -0,0 +1,119 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+)
+
+
+type closer_TestNewScope struct {
+	closed *[]int
+	number int
+}
+
+func (c *closer_TestNewScope) Close() error {
+	*c.closed = append(*c.closed, c.number)
+	return nil
+}
+
+
+func TestNewScope(t *testing.T) {
+
+	container := New()
+	container.Register("fruit", "apple")
+	container.Register("shadowed", "parent")
+
+	scope := container.NewScope()
+
+	if err := scope.Register("shadowed", "scope"); nil != err {
+		t.Errorf("Received an error when registering with the scope: (%T) %v.", err, err)
+		return
+	}
+	if err := scope.Register("request", "GET /"); nil != err {
+		t.Errorf("Received an error when registering with the scope: (%T) %v.", err, err)
+		return
+	}
+
+	tests := []struct{
+		Container Container
+		Name      string
+		Expected  interface{}
+		NotFound  bool
+	}{
+		{Container:scope,     Name:"fruit",    Expected:"apple"},
+		{Container:scope,     Name:"shadowed", Expected:"scope"},
+		{Container:scope,     Name:"request",  Expected:"GET /"},
+		{Container:container, Name:"shadowed", Expected:"parent"},
+		{Container:container, Name:"request",  NotFound:true},
+	}
+
+	for testNumber, test := range tests {
+		dependency, err := test.Container.Get(test.Name)
+		if test.NotFound {
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("For test #%d, expected a DependenciesNotFoundComplainer, but actually got: (%T) %v.", testNumber, err, err)
+			}
+			continue
+		}
+		if nil != err {
+			t.Errorf("For test #%d, received an error: (%T) %v.", testNumber, err, err)
+			continue
+		}
+		if expected, actual := test.Expected, dependency; expected != actual {
+			t.Errorf("For test #%d, expected %v, but actually got %v.", testNumber, expected, actual)
+			continue
+		}
+	}
+}
+
+
+func TestRegisterScoped(t *testing.T) {
+
+	var closed []int
+	var count int
+
+	container := New()
+	container.RegisterScoped("unit-of-work", func(ctx context.Context) (interface{}, error) {
+		count++
+		return &closer_TestNewScope{closed:&closed, number:count}, nil
+	})
+	container.RegisterScoped("other", func(ctx context.Context) (interface{}, error) {
+		count++
+		return &closer_TestNewScope{closed:&closed, number:count}, nil
+	})
+
+	scope1 := container.NewScope()
+	scope2 := container.NewScope()
+
+	a1, _ := scope1.Get("unit-of-work")
+	a2, _ := scope1.Get("unit-of-work")
+	b1, _ := scope2.Get("unit-of-work")
+	o1, _ := scope1.Get("other")
+
+	if a1 != a2 {
+		t.Errorf("Expected the same scope to return the same instance, but it didn't.")
+		return
+	}
+	if a1 == b1 {
+		t.Errorf("Expected different scopes to return different instances, but they didn't.")
+		return
+	}
+	if nil == o1 {
+		t.Errorf("Did not expect nil.")
+		return
+	}
+
+	if err := scope1.Close(); nil != err {
+		t.Errorf("Received an error when closing the scope: (%T) %v.", err, err)
+		return
+	}
+
+	// scope1 constructed #1 and then #3, so they should be closed in the reverse order.
+	if expected, actual := []int{3, 1}, closed; len(expected) != len(actual) || expected[0] != actual[0] || expected[1] != actual[1] {
+		t.Errorf("Expected %v to have been closed, but actually %v were.", expected, actual)
+		return
+	}
+}
@@ -3,6 +3,9 @@ package container
 
 import (
 	"testing"
+
+	"errors"
+	"reflect"
 )
 
 
@@ -26,3 +29,77 @@ func TestNew(t *testing.T) {
 		return
 	}
 }
+
+
+type thingDependencies_TestContainerNew struct {
+	Banana string `inject:"banana"`
+}
+
+type thing_TestContainerNew struct {
+	dependencies thingDependencies_TestContainerNew
+	Apple        int `inject:"apple"`
+	initialized  string
+}
+
+func (thing *thing_TestContainerNew) Dependencies() interface{} {
+	return &thing.dependencies
+}
+
+func (thing *thing_TestContainerNew) AfterInject() error {
+	if 0 > thing.Apple {
+		return errors.New("negative apple")
+	}
+
+	thing.initialized = thing.dependencies.Banana
+	return nil
+}
+
+
+func TestContainerNew(t *testing.T) {
+
+	container := New()
+	container.Register("apple", 1)
+	container.Register("banana", "yellow")
+
+	for testNumber, typ := range []interface{}{
+		(*thing_TestContainerNew)(nil),
+		&thing_TestContainerNew{},
+		reflect.TypeOf((*thing_TestContainerNew)(nil)),
+	} {
+		dependency, err := container.New(typ)
+		if nil != err {
+			t.Errorf("For test #%d, received an error when making a new thing: (%T) %v.", testNumber, err, err)
+			continue
+		}
+
+		thing, ok := dependency.(*thing_TestContainerNew)
+		if !ok {
+			t.Errorf("For test #%d, expected a *thing_TestContainerNew, but actually got %T.", testNumber, dependency)
+			continue
+		}
+
+		if 1 != thing.Apple || "yellow" != thing.dependencies.Banana || "yellow" != thing.initialized {
+			t.Errorf("For test #%d, unexpected thing: %#v", testNumber, thing)
+			continue
+		}
+	}
+
+	if _, err := container.New(thing_TestContainerNew{}); nil == err {
+		t.Errorf("Expected an error when passing a struct (rather than a pointer to one), but did not receive one.")
+		return
+	}
+
+	negative := New()
+	negative.Register("apple", -1)
+	negative.Register("banana", "yellow")
+	if _, err := negative.New((*thing_TestContainerNew)(nil)); !errors.Is(err, ErrAfterInject) {
+		t.Errorf("Expected an AfterInjectComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	missing := New()
+	if _, err := missing.New((*thing_TestContainerNew)(nil)); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a DependenciesNotFoundComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+}
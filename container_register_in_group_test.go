@@ -0,0 +1,132 @@
+package container
+
+
+import (
+	"testing"
+
+	"errors"
+)
+
+
+type route_TestRegisterInGroup interface {
+	Path() string
+}
+
+type pathRoute_TestRegisterInGroup string
+
+func (route pathRoute_TestRegisterInGroup) Path() string {
+	return string(route)
+}
+
+
+func TestRegisterInGroup(t *testing.T) {
+
+	container := New()
+
+	container.RegisterInGroup("http-routes", pathRoute_TestRegisterInGroup("/apple"))
+	container.RegisterInGroup("http-routes", pathRoute_TestRegisterInGroup("/banana"))
+
+	scope := container.NewScope()
+	scope.RegisterInGroup("http-routes", pathRoute_TestRegisterInGroup("/cherry"))
+
+	type Server struct {
+		Routes []route_TestRegisterInGroup `inject:"group:http-routes"`
+	}
+
+	server := new(Server)
+	if err := scope.Inject(server); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+
+	expected := []string{"/apple", "/banana", "/cherry"}
+	if expected, actual := len(expected), len(server.Routes); expected != actual {
+		t.Errorf("Expected %d routes, but actually got %d.", expected, actual)
+		return
+	}
+	for i, route := range server.Routes {
+		if expected, actual := expected[i], route.Path(); expected != actual {
+			t.Errorf("For route #%d, expected %q, but actually got %q.", i, expected, actual)
+			return
+		}
+	}
+}
+
+
+func TestRegisterNamedInGroup(t *testing.T) {
+
+	container := New()
+
+	container.RegisterNamedInGroup("plugins", "apple", 1)
+	container.RegisterNamedInGroup("plugins", "banana", 2)
+
+	if err := container.RegisterNamedInGroup("plugins", "apple", 3); !errors.Is(err, ErrAlreadyRegistered) {
+		t.Errorf("Expected an AlreadyRegisteredComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	type Loader struct {
+		Plugins map[string]int `inject:"group:plugins"`
+		List    []int          `inject:"group:plugins"`
+	}
+
+	loader := new(Loader)
+	if err := container.Inject(loader); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := 2, len(loader.Plugins); expected != actual {
+		t.Errorf("Expected %d plugins, but actually got %d.", expected, actual)
+		return
+	}
+	if expected, actual := 1, loader.Plugins["apple"]; expected != actual {
+		t.Errorf("Expected %d, but actually got %d.", expected, actual)
+		return
+	}
+	if expected, actual := 2, loader.Plugins["banana"]; expected != actual {
+		t.Errorf("Expected %d, but actually got %d.", expected, actual)
+		return
+	}
+	if expected, actual := 2, len(loader.List); expected != actual {
+		t.Errorf("Expected %d plugins, but actually got %d.", expected, actual)
+		return
+	}
+}
+
+
+func TestRegisterInGroupErrors(t *testing.T) {
+
+	container := New()
+
+	container.RegisterInGroup("numbers", 1)
+	container.RegisterInGroup("numbers", "two")
+
+	type Thing struct {
+		Missing []int `inject:"group:missing"`
+		Numbers []int `inject:"group:numbers"`
+	}
+
+	var thing Thing
+	err := container.Inject(&thing)
+
+	var wrongType WrongTypeComplainer
+	if !errors.As(err, &wrongType) {
+		t.Errorf("Expected a WrongTypeComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+	if expected, actual := "group:numbers", wrongType.DependencyName(); expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	// A group that nothing has been added to is injected as an empty slice.
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected no DependenciesNotFoundComplainer for an empty group, but actually got: (%T) %v.", err, err)
+		return
+	}
+	if nil == thing.Missing || 0 != len(thing.Missing) {
+		t.Errorf("Expected an empty (non-nil) slice for an empty group, but actually got %#v.", thing.Missing)
+		return
+	}
+}
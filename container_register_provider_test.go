@@ -0,0 +1,99 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+)
+
+
+func TestRegisterProvider(t *testing.T) {
+
+	container := New()
+
+	var calls int
+	err := container.RegisterProvider("fruit", func(ctx context.Context) (interface{}, error) {
+		calls++
+		return "apple-banana-cherry", nil
+	})
+	if nil != err {
+		t.Errorf("Received an error when trying to register a provider: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := 0, calls; expected != actual {
+		t.Errorf("Expected the provider to have been called %d times, but actually was called %d times.", expected, actual)
+		return
+	}
+
+	for i:=0; i<3; i++ {
+		dependency, err := container.Get("fruit")
+		if nil != err {
+			t.Errorf("Received an error when trying to get: (%T) %v.", err, err)
+			return
+		}
+
+		if expected, actual := "apple-banana-cherry", dependency; expected != actual {
+			t.Errorf("Expected %q, but actually got %v.", expected, actual)
+			return
+		}
+	}
+
+	if expected, actual := 1, calls; expected != actual {
+		t.Errorf("Expected the provider to have been called %d times, but actually was called %d times.", expected, actual)
+		return
+	}
+
+	if err := container.RegisterProvider("fruit", nil); !errors.Is(err, ErrAlreadyRegistered) {
+		t.Errorf("Expected an AlreadyRegisteredComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+}
+
+
+func TestRegisterProviderError(t *testing.T) {
+
+	container := New()
+
+	cause := errors.New("could not connect")
+
+	container.RegisterProvider("db", func(ctx context.Context) (interface{}, error) {
+		return nil, cause
+	})
+
+	_, err := container.Get("db")
+
+	if _, ok := err.(ProblemProvidingDependencyComplainer); !ok {
+		t.Errorf("Expected a ProblemProvidingDependencyComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	if !errors.Is(err, cause) {
+		t.Errorf("Expected errors.Is(err, cause) to be true, but it wasn't.")
+		return
+	}
+
+	type Thing struct {
+		DB interface{} `inject:"db"`
+	}
+
+	err = container.Inject(new(Thing))
+
+	complainer, ok := err.(ProblemInjectingDependencyComplainer)
+	if !ok {
+		t.Errorf("Expected a ProblemInjectingDependencyComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	if expected, actual := ".DB", complainer.Path(); expected != actual {
+		t.Errorf("Expected path %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	if !errors.Is(err, ErrProblemProvidingDependency) {
+		t.Errorf("Expected errors.Is(err, ErrProblemProvidingDependency) to be true, but it wasn't.")
+		return
+	}
+}
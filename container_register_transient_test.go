@@ -0,0 +1,156 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+	"fmt"
+)
+
+
+type unitOfWork_TestRegisterTransient struct {
+	number int
+}
+
+type worker_TestRegisterTransient struct {
+	UnitOfWork Provider[*unitOfWork_TestRegisterTransient] `inject:"unit-of-work"`
+	Session    Provider[*int]                              `inject:"session"`
+	Name       Provider[string]                            `inject:"name"`
+}
+
+
+func TestRegisterTransient(t *testing.T) {
+
+	var constructed int
+
+	container := New()
+	container.Register("name", "worker")
+	container.RegisterTransient("unit-of-work", func(ctx context.Context) (interface{}, error) {
+		constructed++
+		return &unitOfWork_TestRegisterTransient{number:constructed}, nil
+	})
+	container.RegisterScoped("session", func(ctx context.Context) (interface{}, error) {
+		return new(int), nil
+	})
+
+	first, err := container.Get("unit-of-work")
+	if nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+	second, err := container.Get("unit-of-work")
+	if nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+	if first == second {
+		t.Errorf("Expected a new dependency each time, but got the same one twice.")
+		return
+	}
+
+	description, err := container.Describe("unit-of-work")
+	if nil != err {
+		t.Errorf("Received an error when describing: (%T) %v.", err, err)
+		return
+	}
+	if expected, actual := LifetimeTransient, description.Lifetime; expected != actual {
+		t.Errorf("Expected lifetime %s, but actually got %s.", expected, actual)
+		return
+	}
+	if expected, actual := "", description.Type; expected != actual {
+		t.Errorf("Expected type %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	scope := container.NewScope()
+	defer scope.Close()
+
+	var worker worker_TestRegisterTransient
+	if err := scope.Inject(&worker); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+
+	var numbers []int
+	for i:=0; i<2; i++ {
+		unitOfWork, err := worker.UnitOfWork()
+		if nil != err {
+			t.Errorf("Received an error when calling the Provider: (%T) %v.", err, err)
+			return
+		}
+
+		numbers = append(numbers, unitOfWork.number)
+	}
+	if expected, actual := "[3 4]", fmt.Sprint(numbers); expected != actual {
+		t.Errorf("Expected numbers %s, but actually got %s.", expected, actual)
+		return
+	}
+
+	session, err := worker.Session()
+	if nil != err {
+		t.Errorf("Received an error when calling the Provider: (%T) %v.", err, err)
+		return
+	}
+	scopeSession, err := scope.Get("session")
+	if nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+	if session != scopeSession {
+		t.Errorf("Expected the Provider to give the scope's session, but it didn't.")
+		return
+	}
+
+	if name, err := worker.Name(); nil != err || "worker" != name {
+		t.Errorf("Expected name %q, but actually got %q (and error: %v).", "worker", name, err)
+		return
+	}
+}
+
+
+func TestRegisterTransientErrors(t *testing.T) {
+
+	container := New()
+	container.RegisterTransient("apple", func(ctx context.Context) (interface{}, error) {
+		return nil, errors.New("no apple")
+	})
+	container.RegisterTransient("banana", func(ctx context.Context) (interface{}, error) {
+		return "two", nil
+	})
+
+	if _, err := container.Get("apple"); !errors.Is(err, ErrProblemProvidingDependency) {
+		t.Errorf("Expected a ProblemProvidingDependencyComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	if err := container.Register("apple", 1); !errors.Is(err, ErrAlreadyRegistered) {
+		t.Errorf("Expected an AlreadyRegisteredComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	var thing struct{
+		Banana Provider[int] `inject:"banana"`
+	}
+	if err := container.Inject(&thing); nil != err {
+		t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+		return
+	}
+	if _, err := thing.Banana(); !errors.Is(err, ErrWrongType) {
+		t.Errorf("Expected a WrongTypeComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+
+	var missing struct{
+		Cherry Provider[int] `inject:"cherry"`
+	}
+	if err := container.Inject(&missing); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected a DependenciesNotFoundComplainer, but actually got: (%T) %v.", err, err)
+		return
+	}
+	if nil != missing.Cherry {
+		t.Errorf("Expected the Provider to not be injected into, but it was.")
+		return
+	}
+}
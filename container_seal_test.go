@@ -0,0 +1,190 @@
+package container
+
+
+import (
+	"testing"
+
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+
+func TestSeal(t *testing.T) {
+
+	container := New()
+	container.Register("apple", 1)
+	container.RegisterProvider("banana", func(ctx context.Context) (interface{}, error) {
+		return 2, nil
+	})
+	container.Alias("cherry", "apple")
+	container.RegisterInGroup("fruits", 3)
+
+	snapshot := container.Snapshot()
+	cherrySnapshot := container.Snapshot("cherry")
+
+	container.Seal()
+	container.Seal()
+
+	provider := func(ctx context.Context) (interface{}, error) {
+		return nil, nil
+	}
+	decorator := func(old interface{}) (interface{}, error) {
+		return old, nil
+	}
+
+	tests := []struct{
+		Name string
+		Func func() error
+	}{
+		{Name:"date",          Func:func() error { return container.Register("date", 4) }},
+		{Name:"date",          Func:func() error { return container.RegisterProvider("date", provider) }},
+		{Name:"date",          Func:func() error { return container.RegisterScoped("date", provider) }},
+		{Name:"apple",         Func:func() error { return container.Replace("apple", 5) }},
+		{Name:"apple",         Func:func() error { return container.Decorate("apple", decorator) }},
+		{Name:"banana",        Func:func() error { return container.Decorate("banana", decorator) }},
+		{Name:"date",          Func:func() error { return container.Alias("date", "apple") }},
+		{Name:"group:fruits",  Func:func() error { return container.RegisterInGroup("fruits", 6) }},
+		{Name:"group:fruits",  Func:func() error { return container.RegisterNamedInGroup("fruits", "fig", 7) }},
+		{Name:"kitchen.date",  Func:func() error { return container.Namespace("kitchen.").Register("date", 8) }},
+		{Name:"",              Func:func() error { return container.Restore(snapshot) }},
+		{Name:"apple",         Func:func() error { return container.Restore(cherrySnapshot) }},
+	}
+
+	for testNumber, test := range tests {
+		err := test.Func()
+		if !errors.Is(err, ErrSealed) {
+			t.Errorf("For test #%d, expected a SealedComplainer, but actually got: (%T) %v.", testNumber, err, err)
+			continue
+		}
+
+		var complainer SealedComplainer
+		if !errors.As(err, &complainer) {
+			t.Errorf("For test #%d, expected errors.As to find a SealedComplainer, but it didn't.", testNumber)
+			continue
+		}
+
+		if expected, actual := test.Name, complainer.DependencyName(); expected != actual {
+			t.Errorf("For test #%d, expected dependency name %q, but actually got %q.", testNumber, expected, actual)
+			continue
+		}
+	}
+
+	type Thing struct {
+		Apple  int   `inject:"apple"`
+		Banana int   `inject:"banana"`
+		Cherry int   `inject:"cherry"`
+		Fruits []int `inject:"group:fruits"`
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var thing Thing
+			if err := container.Inject(&thing); nil != err {
+				t.Errorf("Received an error when injecting: (%T) %v.", err, err)
+				return
+			}
+
+			if 1 != thing.Apple || 2 != thing.Banana || 1 != thing.Cherry || 1 != len(thing.Fruits) || 3 != thing.Fruits[0] {
+				t.Errorf("Unexpected injected values: %#v", thing)
+				return
+			}
+		}()
+	}
+	wg.Wait()
+
+	scope := container.NewScope()
+	if err := scope.Register("date", 4); nil != err {
+		t.Errorf("Expected to be able to register with a scope of a sealed container, but received: (%T) %v.", err, err)
+		return
+	}
+}
+
+
+func TestReplace(t *testing.T) {
+
+	container := New()
+	container.Register("apple", 1)
+	container.Alias("cherry", "apple")
+
+	if err := container.Replace("cherry", 2); nil != err {
+		t.Errorf("Received an error when replacing: (%T) %v.", err, err)
+		return
+	}
+
+	for _, name := range []string{"apple", "cherry"} {
+		dependency, err := container.Get(name)
+		if nil != err {
+			t.Errorf("Received an error when getting %q: (%T) %v.", name, err, err)
+			return
+		}
+
+		if expected, actual := 2, dependency; expected != actual {
+			t.Errorf("For %q, expected %v, but actually got %v.", name, expected, actual)
+			return
+		}
+	}
+
+	if err := container.Replace("banana", 3); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected replacing something not registered to fail, but actually got: (%T) %v.", err, err)
+		return
+	}
+}
+
+
+func TestSealScope(t *testing.T) {
+
+	container := New()
+	container.Register("apple", 1)
+	container.Seal()
+
+	scope := container.NewScope()
+	scope.Register("banana", 2)
+
+	// Hold the sealed container's lock. Getting "apple" through the scope should not need it.
+	sealedMutex := container.(*internalContainer).mutex
+	sealedMutex.Lock()
+
+	done := make(chan interface{})
+	go func() {
+		dependency, _ := scope.Get("apple")
+		done <- dependency
+	}()
+
+	select {
+	case dependency := <-done:
+		if expected, actual := 1, dependency; expected != actual {
+			t.Errorf("Expected %v, but actually got %v.", expected, actual)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("Expected getting through the scope to not take the sealed container's lock, but it did.")
+	}
+
+	sealedMutex.Unlock()
+
+	if dependency, err := scope.Get("banana"); nil != err || 2 != dependency {
+		t.Errorf("Expected %v, but actually got: %v, (%T) %v.", 2, dependency, err, err)
+		return
+	}
+
+	// What the scope has of its own is what the scope sees.
+	other := container.NewScope()
+	other.Register("apple", 3)
+	if dependency, err := other.Get("apple"); nil != err || 3 != dependency {
+		t.Errorf("Expected %v, but actually got: %v, (%T) %v.", 3, dependency, err, err)
+		return
+	}
+	decorated := container.NewScope()
+	decorated.Decorate("apple", func(old interface{}) (interface{}, error) {
+		return old.(int) * 10, nil
+	})
+	if dependency, err := decorated.Get("apple"); nil != err || 10 != dependency {
+		t.Errorf("Expected %v, but actually got: %v, (%T) %v.", 10, dependency, err, err)
+		return
+	}
+}
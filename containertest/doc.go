@@ -0,0 +1,22 @@
+/*
+Package containertest provides helpers for using 'dependency injection containers' in tests.
+
+Rather than building a fresh container (and copying all the registering) for each test,
+a test can swap out a single dependency for a fake one, with the Override func. When the
+test (or subtest) finishes, what was registered before gets put back.
+
+For example:
+
+	func TestCheckout(t *testing.T) {
+		containertest.Override(t, app.Container, "payment-gateway", new(FakePaymentGateway))
+
+		//@TODO
+	}
+
+The Snapshot func is similar, except that it puts back everything registered with the
+container when the test finishes, whatever the test did to it.
+
+Since each of these changes the container, tests using them on the same container should
+not be run in parallel (see testing.T's Parallel method).
+*/
+package containertest
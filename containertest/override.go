@@ -0,0 +1,51 @@
+package containertest
+
+
+import (
+	"github.com/reiver/go-container"
+
+	"testing"
+)
+
+
+// Override replaces the dependency registered (with the container) with the name, with the fake,
+// until the test finishes. Then (using t.Cleanup) what was registered before is put back.
+//
+// Only that one registration is put back. Everything else done to the container after Override
+// was called is left as is. (To undo that too, see the Snapshot func.) And so, for example, a
+// dependency that was provided (see the container's RegisterProvider method) during the test is
+// not provided again after it.
+//
+// If the dependency cannot be replaced (for example, because nothing is registered with that
+// name, or because the container is sealed) then the test is failed (with t.Fatalf).
+func Override(t testing.TB, c container.Container, name string, fake interface{}) {
+	t.Helper()
+
+	snapshot := c.Snapshot(name)
+
+	if err := c.Replace(name, fake); nil != err {
+		t.Fatalf("containertest: could not override dependency %q: %v", name, err)
+		return
+	}
+
+	t.Cleanup(func() {
+		if err := c.Restore(snapshot); nil != err {
+			t.Errorf("containertest: could not restore container after overriding dependency %q: %v", name, err)
+		}
+	})
+}
+
+
+// Snapshot makes it so that, when the test finishes, what is registered with the container
+// is put back to what it is now (using t.Cleanup and the container's Snapshot and Restore methods).
+func Snapshot(t testing.TB, c container.Container) {
+	t.Helper()
+
+	snapshot := c.Snapshot()
+
+	t.Cleanup(func() {
+		if err := c.Restore(snapshot); nil != err {
+			t.Errorf("containertest: could not restore container: %v", err)
+		}
+	})
+}
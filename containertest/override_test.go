@@ -0,0 +1,110 @@
+package containertest
+
+
+import (
+	"github.com/reiver/go-container"
+
+	"testing"
+
+	"context"
+)
+
+
+func TestOverride(t *testing.T) {
+
+	var provided int
+
+	c := container.New()
+	c.RegisterProvider("clock", func(ctx context.Context) (interface{}, error) {
+		return "real clock", nil
+	})
+	c.RegisterProvider("database", func(ctx context.Context) (interface{}, error) {
+		provided++
+		return "database", nil
+	})
+	c.Register("greeting", "hello")
+
+	t.Run("override", func(t *testing.T) {
+		Override(t, c, "clock", "fake clock")
+
+		if err := c.Register("extra", "extra"); nil != err {
+			t.Errorf("Received an error when registering: (%T) %v.", err, err)
+			return
+		}
+
+		if _, err := c.Get("database"); nil != err {
+			t.Errorf("Received an error when getting: (%T) %v.", err, err)
+			return
+		}
+
+		dependency, err := c.Get("clock")
+		if nil != err {
+			t.Errorf("Received an error when getting: (%T) %v.", err, err)
+			return
+		}
+		if expected, actual := "fake clock", dependency; expected != actual {
+			t.Errorf("Expected %q, but actually got %v.", expected, actual)
+			return
+		}
+	})
+
+	dependency, err := c.Get("clock")
+	if nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+	if expected, actual := "real clock", dependency; expected != actual {
+		t.Errorf("Expected %q, but actually got %v.", expected, actual)
+		return
+	}
+
+	if _, err := c.Get("database"); nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+	if expected, actual := 1, provided; expected != actual {
+		t.Errorf("Expected the provider to be called %d time, but it was actually called %d times.", expected, actual)
+		return
+	}
+
+	if !c.Has("extra") {
+		t.Errorf("Expected what was registered during the subtest to still be there, but it wasn't.")
+		return
+	}
+
+	if !c.Has("greeting") {
+		t.Errorf("Expected what was registered before the subtest to still be there, but it wasn't.")
+		return
+	}
+}
+
+
+func TestSnapshot(t *testing.T) {
+
+	c := container.New()
+	c.Register("greeting", "hello")
+
+	t.Run("snapshot", func(t *testing.T) {
+		Snapshot(t, c)
+
+		c.Decorate("greeting", func(old interface{}) (interface{}, error) {
+			return old.(string) + " world", nil
+		})
+		c.Namespace("kitchen.").Register("knife", "knife")
+	})
+
+	dependency, err := c.Get("greeting")
+	if nil != err {
+		t.Errorf("Received an error when getting: (%T) %v.", err, err)
+		return
+	}
+	if expected, actual := "hello", dependency; expected != actual {
+		t.Errorf("Expected %q, but actually got %v.", expected, actual)
+		return
+	}
+
+	if expected, actual := 1, len(c.Names()); expected != actual {
+		t.Errorf("Expected %d name, but actually got %d: %v", expected, actual, c.Names())
+		return
+	}
+}
@@ -0,0 +1,72 @@
+package container
+
+
+import (
+	"fmt"
+	"strings"
+)
+
+
+// CycleComplainer is an 'error' that represents the situation where resolving a
+// dependency needed that same dependency (directly, or through other dependencies).
+// As in, the provider for "a" needed "b", and the provider for "b" needed "a".
+//
+// The Cycle method returns the names of the dependencies, in the order they were
+// being resolved, ending with the name that was needed again. As in:
+//
+//	[]string{"a", "b", "a"}
+//
+// Cycles are only found if each provider passes the context.Context it is given
+// along (as in, to GetContext or InjectContext).
+//
+// errors.Is(err, ErrCycle) reports true for a CycleComplainer.
+type CycleComplainer interface {
+	error
+	CycleComplainer()
+	Cycle() []string
+}
+
+
+// internalCycleComplainer is the only underlying implementation that fits the
+// CycleComplainer interface, in this library.
+type internalCycleComplainer struct {
+	cycle []string
+}
+
+
+// newCycleComplainer creates a new internalCycleComplainer (struct) and
+// returns it as a CycleComplainer (interface).
+func newCycleComplainer(cycle ...string) CycleComplainer {
+	complainer := internalCycleComplainer{
+		cycle:append([]string(nil), cycle...),
+	}
+
+	return &complainer
+}
+
+
+func (complainer *internalCycleComplainer) Error() string {
+	quoted := make([]string, len(complainer.cycle))
+	for i, name := range complainer.cycle {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+
+	return fmt.Sprintf("Dependency cycle: %s", strings.Join(quoted, " -> "))
+}
+
+
+func (complainer *internalCycleComplainer) CycleComplainer() {
+	// Nothing here.
+}
+
+
+// Cycle method is necessary to satisfy the 'CycleComplainer' interface.
+func (complainer *internalCycleComplainer) Cycle() []string {
+	return append([]string(nil), complainer.cycle...)
+}
+
+
+// Is makes it so errors.Is(err, ErrCycle) works.
+func (complainer *internalCycleComplainer) Is(target error) bool {
+	return ErrCycle == target
+}
@@ -0,0 +1,71 @@
+package container
+
+
+import (
+	"context"
+	"reflect"
+)
+
+
+// deferredInjectable is what injectPtr uses to recognize a struct field (from a pointer to it)
+// whose dependency is resolved later on, rather than when injecting into it, and to inject into it.
+//
+// Lazy[T] and Provider[T] are deferredInjectables.
+type deferredInjectable interface {
+	deferredType() reflect.Type
+	injectDeferred(resolve func() (interface{}, error))
+}
+
+
+var deferredInjectableType = reflect.TypeOf((*deferredInjectable)(nil)).Elem()
+
+
+// injectDeferred injects into the (Lazy[T] or Provider[T]) struct field, a func that resolves the
+// dependency for the `inject` struct tag (the way injectPtr would have) each time it is called.
+//
+// If the dependency is not registered, then ok is false. (Unless the `inject` struct tag is a
+// "group:" or "prefix:" one, since it is fine for those to have nothing in them.)
+func (container *internalContainer) injectDeferred(ctx context.Context, deferred deferredInjectable, field plannedField, fieldPath string) (ok bool) {
+	if !field.tag.group && !field.tag.prefix && !field.tag.self {
+		if _, found := container.lookup(container.qualify(field.tag.name)); !found {
+			return false
+		}
+	}
+
+	// The dependency might be resolved long after the struct was injected into. So whether
+	// the context.Context is canceled (or its deadline is exceeded) is not passed along. And
+	// neither is what was being resolved at the time (since that is done by then).
+	ctx = withoutResolving(context.WithoutCancel(ctx))
+
+	typ := deferred.deferredType()
+
+	deferred.injectDeferred(func() (interface{}, error) {
+		dependency, ok, err := container.resolveTag(ctx, field.tag, field.requiredBy, typ, fieldPath)
+		if nil != err {
+			return nil, err
+		}
+		if !ok {
+			return nil, newDependenciesNotFoundComplainer(field.dependencyName)
+		}
+
+		value := reflect.ValueOf(dependency)
+		if !value.IsValid() {
+			return nil, nil
+		}
+
+		value, ok = assignable(value, typ, field.tag.method)
+		if !ok {
+			return nil, newWrongTypeComplainer(field.dependencyName, fieldPath)
+		}
+
+		// Go through a T (rather than returning value.Interface()) since value might
+		// only be assignable to a T, rather than be one. (Which the type assertions in
+		// Lazy[T] and Provider[T] need it to be.)
+		converted := reflect.New(typ).Elem()
+		converted.Set(value)
+
+		return converted.Interface(), nil
+	})
+
+	return true
+}
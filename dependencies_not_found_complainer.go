@@ -5,6 +5,8 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 )
 
 
@@ -45,59 +47,122 @@ const dependenciesNotFoundMessagePrefix = "Dependencies not found"
 //
 // You can get a list of the the missing dependency names
 // by caling the MissingDependencyNames method.
+//
+// You can find out what required a missing dependency by
+// calling the RequiredBy method. And where each of those are,
+// relative to what was passed to the Inject method, by calling
+// the Paths method. (The two returned slices line up with each
+// other.)
+//
+// errors.Is(err, ErrNotFound) reports true for a DependenciesNotFoundComplainer.
 type DependenciesNotFoundComplainer interface {
 	Error() string
 	MissingDependencyNames() []string
+	RequiredBy(string) []string
+	Paths(string) []string
 }
 
 // internalDependenciesNotFoundComplainer is the only underlying implementation that fits the
 // DependenciesNotFoundComplainer interface, in this library.
+//
+// The keys of the missingDependencyNames map are the missing dependency names,
+// and the values are what required each of them (which might be empty).
 type internalDependenciesNotFoundComplainer struct {
-	missingDependencyNames map[string]struct{}
+	missingDependencyNames map[string][]dependencyRequirement
+}
+
+// dependencyRequirement represents something that required a dependency.
+//
+// requiredBy is usually a struct type and field name, as in:
+//
+//	*billing.Service.DB
+//
+// And path is where that struct field is, relative to what was passed to
+// the Inject method, as in:
+//
+//	["eu-west"][3].Dependencies().DB
+type dependencyRequirement struct {
+	requiredBy string
+	path string
+}
+
+// String returns the requiredBy, followed by the path (if the path adds
+// anything to it). As in:
+//
+//	*billing.Service.DB at ["eu-west"][3].Dependencies().DB
+func (requirement dependencyRequirement) String() string {
+	if strings.HasSuffix(requirement.requiredBy, requirement.path) {
+		return requirement.requiredBy
+	}
+
+	return requirement.requiredBy + " at " + requirement.path
+}
+
+// less is used to keep the requirements in sorted order.
+func (requirement dependencyRequirement) less(other dependencyRequirement) bool {
+	if requirement.requiredBy != other.requiredBy {
+		return requirement.requiredBy < other.requiredBy
+	}
+
+	return requirement.path < other.path
 }
 
 // newDependenciesNotFoundComplainer creates a new internalDependenciesNotFoundComplainer (struct) and
 // returns it as a DependenciesNotFoundComplainer (interface).
 func newDependenciesNotFoundComplainer(missingDependencies ...string) DependenciesNotFoundComplainer {
-	missingDependencyNames := make(map[string]struct{})
+	missingDependencyNames := make(map[string][]dependencyRequirement)
+
+	err := internalDependenciesNotFoundComplainer{
+		missingDependencyNames:missingDependencyNames,
+	}
 
 	if 0 < len(missingDependencies) {
 		for _, name := range missingDependencies {
-			missingDependencyNames[name] = struct{}{}
+			err.insert(name)
 		}
 	}
 
-	err := internalDependenciesNotFoundComplainer{
-		missingDependencyNames:missingDependencyNames,
-	}
-
 	return &err
 }
 
 
 // Error method is necessary to satisfy the 'error' interface (and the DependenciesNotFoundComplainer
 // interface).
+//
+// The missing dependency names are in sorted order. And, for each missing dependency name,
+// what required it is also included (in sorted order). For example:
+//
+//	Dependencies not found: "cache" required by *billing.Service.Cache; "db" required by *audit.Writer.Store, *billing.Service.DB
 func (err *internalDependenciesNotFoundComplainer) Error() string {
 	var buffer bytes.Buffer
 
 	io.WriteString(&buffer, dependenciesNotFoundMessagePrefix)
-	i := 0
-	for key,_ := range err.missingDependencyNames {
+	for i, name := range err.MissingDependencyNames() {
 		if 0 == i {
 			io.WriteString(&buffer, ": ")
 		} else {
-			io.WriteString(&buffer, ", ")
+			io.WriteString(&buffer, "; ")
 		}
 
-		io.WriteString(&buffer, fmt.Sprintf("%q", key))
+		io.WriteString(&buffer, fmt.Sprintf("%q", name))
 
-		i++
+		for j, requirement := range err.missingDependencyNames[name] {
+			if 0 == j {
+				io.WriteString(&buffer, " required by ")
+			} else {
+				io.WriteString(&buffer, ", ")
+			}
+
+			io.WriteString(&buffer, requirement.String())
+		}
 	}
 
 	return buffer.String()
 }
 
 // MissingDependencyNames method is necessary to satisfy the 'DependenciesNotFoundComplainer' interface.
+//
+// The missing dependency names are returned in sorted order.
 func (err *internalDependenciesNotFoundComplainer) MissingDependencyNames() []string {
 	sliceLength := len(err.missingDependencyNames)
 
@@ -109,6 +174,42 @@ func (err *internalDependenciesNotFoundComplainer) MissingDependencyNames() []st
 		i++
 	}
 
+	sort.Strings(slice)
+
+	return slice
+}
+
+// RequiredBy method is necessary to satisfy the 'DependenciesNotFoundComplainer' interface.
+//
+// It returns what required the missing dependency (with the given name), in sorted order.
+// What required it is usually a struct type and field name, as in:
+//
+//	*billing.Service.DB
+func (err *internalDependenciesNotFoundComplainer) RequiredBy(dependencyName string) []string {
+	requirements := err.missingDependencyNames[dependencyName]
+
+	slice := make([]string, len(requirements))
+	for i, requirement := range requirements {
+		slice[i] = requirement.requiredBy
+	}
+
+	return slice
+}
+
+// Paths method is necessary to satisfy the 'DependenciesNotFoundComplainer' interface.
+//
+// It returns where each of the things returned by the RequiredBy method are, relative
+// to what was passed to the Inject method, as in:
+//
+//	["eu-west"][3].Dependencies().DB
+func (err *internalDependenciesNotFoundComplainer) Paths(dependencyName string) []string {
+	requirements := err.missingDependencyNames[dependencyName]
+
+	slice := make([]string, len(requirements))
+	for i, requirement := range requirements {
+		slice[i] = requirement.path
+	}
+
 	return slice
 }
 
@@ -126,18 +227,87 @@ func (err *internalDependenciesNotFoundComplainer) concatenate(otherComplainer D
 	}
 
 	for _, name := range moreMissingDependencyNames {
-		err.missingDependencyNames[name] = struct{}{}
+		err.insert(name)
+
+		requiredBy := otherComplainer.RequiredBy(name)
+		paths      := otherComplainer.Paths(name)
+		for i := range requiredBy {
+			err.insertRequirement(name, requiredBy[i], paths[i])
+		}
 	}
 }
 
 
-// insert is a helper method that adds in a missing dependency.
-func (err *internalDependenciesNotFoundComplainer) insert(dependencyName string) {
-	err.missingDependencyNames[dependencyName] = struct{}{}
+// insert is a helper method that adds in a missing dependency, along with (optionally)
+// what required it.
+func (err *internalDependenciesNotFoundComplainer) insert(dependencyName string, requiredBy ...string) {
+	if _, ok := err.missingDependencyNames[dependencyName]; !ok {
+		err.missingDependencyNames[dependencyName] = nil
+	}
+
+	for _, requirer := range requiredBy {
+		err.insertRequirement(dependencyName, requirer, "")
+	}
+}
+
+
+// insertRequirement is a helper method that adds in a missing dependency, along with
+// what required it and where that is.
+func (err *internalDependenciesNotFoundComplainer) insertRequirement(dependencyName string, requiredBy string, path string) {
+	requirement := dependencyRequirement{
+		requiredBy:requiredBy,
+		path:path,
+	}
+
+	existing := err.missingDependencyNames[dependencyName]
+
+	i := sort.Search(len(existing), func(i int) bool {
+		return !existing[i].less(requirement)
+	})
+	if i < len(existing) && requirement == existing[i] {
+		return
+	}
+
+	existing = append(existing, dependencyRequirement{})
+	copy(existing[i+1:], existing[i:])
+	existing[i] = requirement
+
+	err.missingDependencyNames[dependencyName] = existing
 }
 
 
 // len is a helper method that returns the count of the number of missing dependencies.
+//
+// A nil *internalDependenciesNotFoundComplainer has a length of 0. (So that one
+// does not have to be created until there is something missing.)
 func (err *internalDependenciesNotFoundComplainer) len() int {
+	if nil == err {
+		return 0
+	}
+
 	return len(err.missingDependencyNames)
 }
+
+
+// Is makes it so errors.Is(err, ErrNotFound) works.
+func (err *internalDependenciesNotFoundComplainer) Is(target error) bool {
+	return ErrNotFound == target
+}
+
+
+// with is a helper method that returns the other error together with this one.
+//
+// If there are no missing dependencies, then the other error is returned as is.
+// If the other error is nil, then this one is returned as is.
+// Else an InjectionComplainer is returned that contains both of them.
+func (err *internalDependenciesNotFoundComplainer) with(other error) error {
+	if 0 >= err.len() {
+		return other
+	}
+
+	if nil == other {
+		return err
+	}
+
+	return newInjectionComplainer(err, other)
+}
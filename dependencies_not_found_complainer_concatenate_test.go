@@ -284,7 +284,7 @@ func TestConcatenate(t *testing.T) {
 		}
 
 		if expected, actual := test.ExpectedFinalLen, len(internalComplainer.missingDependencyNames); expected != actual {
-			t.Errorf("For test %#d, expected the FINAL length of the map used to store the missing dependency names to be %d, but actually was %d.\nTest: %#v\nCopy of Original Complainer: %#v", testNumber, expected, actual, test, originalComplainerCopy)
+			t.Errorf("For test %d, expected the FINAL length of the map used to store the missing dependency names to be %d, but actually was %d.\nTest: %#v\nCopy of Original Complainer: %#v", testNumber, expected, actual, test, originalComplainerCopy)
 			return
 		}
 
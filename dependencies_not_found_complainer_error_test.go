@@ -0,0 +1,112 @@
+package container
+
+
+import (
+	"testing"
+)
+
+
+func TestDependenciesNotFoundComplainerError(t *testing.T) {
+
+	type insertion struct {
+		Name       string
+		RequiredBy []string
+	}
+
+	tests := []struct{
+		Insertions []insertion
+		Expected   string
+	}{
+		{
+			Insertions: []insertion{},
+			Expected:   `Dependencies not found`,
+		},
+		{
+			Insertions: []insertion{
+				{Name:"apple"},
+			},
+			Expected:   `Dependencies not found: "apple"`,
+		},
+		{
+			Insertions: []insertion{
+				{Name:"cherry"},
+				{Name:"apple"},
+				{Name:"banana"},
+			},
+			Expected:   `Dependencies not found: "apple"; "banana"; "cherry"`,
+		},
+		{
+			Insertions: []insertion{
+				{Name:"db", RequiredBy:[]string{"*billing.Service.DB"}},
+				{Name:"db", RequiredBy:[]string{"*audit.Writer.Store"}},
+			},
+			Expected:   `Dependencies not found: "db" required by *audit.Writer.Store, *billing.Service.DB`,
+		},
+		{
+			Insertions: []insertion{
+				{Name:"db",    RequiredBy:[]string{"*billing.Service.DB", "*audit.Writer.Store"}},
+				{Name:"cache", RequiredBy:[]string{"*billing.Service.Cache"}},
+				{Name:"db",    RequiredBy:[]string{"*billing.Service.DB"}},
+			},
+			Expected:   `Dependencies not found: "cache" required by *billing.Service.Cache; "db" required by *audit.Writer.Store, *billing.Service.DB`,
+		},
+	}
+
+
+	for testNumber, test := range tests {
+
+		complainer := newDependenciesNotFoundComplainer().(*internalDependenciesNotFoundComplainer)
+
+		for _, insertion := range test.Insertions {
+			complainer.insert(insertion.Name, insertion.RequiredBy...)
+		}
+
+		// Do it a number of times, to make sure that the order does not change.
+		for i:=0; i<20; i++ {
+			if expected, actual := test.Expected, complainer.Error(); expected != actual {
+				t.Errorf("For test #%d, expected %q, but actually got %q.", testNumber, expected, actual)
+				return
+			}
+		}
+	}
+}
+
+
+func TestDependenciesNotFoundComplainerRequiredByFromInject(t *testing.T) {
+
+	type Thing struct {
+		DB    interface{} `inject:"db"`
+		Store interface{} `inject:"db"`
+	}
+
+	container := New()
+
+	err := container.Inject(new(Thing))
+	if nil == err {
+		t.Errorf("Expected Inject to return an error, but it didn't.")
+		return
+	}
+
+	complainer, ok := err.(DependenciesNotFoundComplainer)
+	if !ok {
+		t.Errorf("Expected the error to fit the DependenciesNotFoundComplainer interface, but it didn't. Error: (%T) %q", err, err)
+		return
+	}
+
+	requiredBy := complainer.RequiredBy("db")
+
+	if expected, actual := 2, len(requiredBy); expected != actual {
+		t.Errorf("Expected %d things to have required \"db\", but actually got %d: %q", expected, actual, requiredBy)
+		return
+	}
+
+	if expected, actual := "*container.Thing.DB", requiredBy[0]; expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	if expected, actual := "*container.Thing.Store", requiredBy[1]; expected != actual {
+		t.Errorf("Expected %q, but actually got %q.", expected, actual)
+		return
+	}
+}
@@ -87,7 +87,7 @@ func TestInsert(t *testing.T) {
 		}
 
 		if expected, actual := test.ExpectedFinalLen, len(internalComplainer.missingDependencyNames); expected != actual {
-			t.Errorf("For test %#d, expected the FINAL length of the map used to store the missing dependency names to be %d, but actually was %d.\nTest: %#v", testNumber, expected, actual, test)
+			t.Errorf("For test %d, expected the FINAL length of the map used to store the missing dependency names to be %d, but actually was %d.\nTest: %#v", testNumber, expected, actual, test)
 			return
 		}
 
@@ -82,7 +82,7 @@ func TestLen(t *testing.T) {
 		}
 
 		if expected, actual := test.ExpectedFinalLen, internalComplainer.len(); expected != actual {
-			t.Errorf("For test %#d, expected the FINAL length of the map used to store the missing dependency names to be %d, but actually was %d.\nTest: %#v", testNumber, expected, actual, test)
+			t.Errorf("For test %d, expected the FINAL length of the map used to store the missing dependency names to be %d, but actually was %d.\nTest: %#v", testNumber, expected, actual, test)
 			return
 		}
 	}
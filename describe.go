@@ -0,0 +1,149 @@
+package container
+
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+
+// Description describes a registered dependency. It is what the Describe method returns.
+type Description struct {
+	// Name is the name that was passed to Describe.
+	Name string
+
+	// AliasOf is what the name is an alias for (see Alias), or "" (empty string) if
+	// the name is not an alias.
+	AliasOf string
+
+	// Aliases are all the other names that (directly or indirectly) are aliases for
+	// the same dependency, in sorted order.
+	Aliases []string
+
+	// Type is the type of the dependency, as in "*log.Logger".
+	//
+	// If the dependency has not been constructed yet (see RegisterProvider and
+	// RegisterScoped), or is constructed anew each time (see RegisterTransient),
+	// then Type is "" (empty string).
+	Type string
+
+	// Lifetime is how many instances of the dependency there are, and when they
+	// get constructed.
+	Lifetime Lifetime
+
+	// RegisteredAt is the file and line number that the dependency was registered
+	// from, as in "/home/joeblow/src/example/main.go:26".
+	RegisteredAt string
+
+	// Dependents are the struct fields (as in "*billing.Service.DB") that the
+	// dependency has been injected into (or that there was an attempt to inject it
+	// into), in sorted order.
+	Dependents []string
+}
+
+
+// Has returns whether anything is registered (including as an alias) with the name.
+func (container *internalContainer) Has(dependencyName string) bool {
+	_, ok := container.lookup(container.qualify(dependencyName))
+
+	return ok
+}
+
+
+// Names returns every registered name (including aliases) in sorted order.
+//
+// (If called on a namespace, then the names are relative to the namespace.)
+func (container *internalContainer) Names() []string {
+	return container.List("")
+}
+
+
+// Describe returns a Description of what is registered with the name.
+//
+// This is meant for debugging and health-check tooling. For example:
+//
+//	for _, name := range Container.Names() {
+//		description, err := Container.Describe(name)
+//		if nil != err {
+//			//@TODO
+//		}
+//	
+//		fmt.Printf("%s (%s) registered at %s\n", description.Name, description.Lifetime, description.RegisteredAt)
+//	}
+//
+// If nothing is registered with that name, then a DependenciesNotFoundComplainer is returned.
+func (container *internalContainer) Describe(dependencyName string) (Description, error) {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] Describe(%q)", dependencyName)
+
+	qualifiedName := container.qualify(dependencyName)
+
+	reg, ok := container.lookup(qualifiedName)
+	if !ok {
+		err := newDependenciesNotFoundComplainer(qualifiedName)
+
+		logger.Printf("[END]   Describe(%q) with ERROR: %q", dependencyName, err)
+		return Description{}, err
+	}
+
+	description := Description{
+		Name:dependencyName,
+		Lifetime:reg.lifetime(),
+	}
+
+	if target, ok := container.aliasTarget(qualifiedName); ok {
+		description.AliasOf = container.unqualify(target)
+	}
+
+	for _, name := range container.names() {
+		if name == qualifiedName || !strings.HasPrefix(name, container.namespace) {
+			continue
+		}
+
+		if _, isAlias := container.aliasTarget(name); !isAlias {
+			continue
+		}
+
+		if other, _ := container.lookup(name); reg == other {
+			description.Aliases = append(description.Aliases, container.unqualify(name))
+		}
+	}
+
+	reg.mutex.Lock()
+	if reg.provided {
+		description.Type = fmt.Sprintf("%T", reg.dependency)
+	}
+	description.RegisteredAt = reg.site
+	reg.mutex.Unlock()
+
+	description.Dependents = reg.dependentNames()
+
+	sort.Strings(description.Dependents)
+
+	logger.Printf("[END]   Describe(%q)", dependencyName)
+
+	return description, nil
+}
+
+
+// aliasTarget returns what the name is (directly) an alias for, if it is an alias.
+func (container *internalContainer) aliasTarget(name string) (string, bool) {
+	for c := container; nil != c; c = c.parent {
+		c.mutex.RLock()
+		_, isRegistered := c.registry[name]
+		target, isAlias := c.aliases[name]
+		c.mutex.RUnlock()
+
+		if isRegistered {
+			return "", false
+		}
+		if isAlias {
+			return target, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,37 @@
+package container
+
+
+import (
+	"errors"
+)
+
+
+// The following are 'sentinel errors'.
+//
+// Each of the complainers returned by this library reports itself as
+// being one of these (via an Is method), so that code such as the
+// following works:
+//
+//	if err := Container.Inject(thing); nil != err {
+//		if errors.Is(err, container.ErrNotFound) {
+//			//@TODO
+//		}
+//	}
+//
+// Note that these sentinel errors are never returned directly. What is
+// returned is always a complainer, which can give more details about
+// what went wrong.
+var (
+	ErrAfterInject                = errors.New("problem after injecting dependencies")
+	ErrAlreadyRegistered          = errors.New("dependency already registered")
+	ErrAmbiguousDependency        = errors.New("ambiguous dependency")
+	ErrCanceled                   = errors.New("canceled while resolving dependency")
+	ErrClosed                     = errors.New("container is closed")
+	ErrCycle                      = errors.New("dependency cycle")
+	ErrNotFound                   = errors.New("dependency not found")
+	ErrProblemInjectingDependency = errors.New("problem injecting dependency")
+	ErrProblemProvidingDependency = errors.New("problem providing dependency")
+	ErrSealed                     = errors.New("container is sealed")
+	ErrUnsettableField            = errors.New("unsettable field")
+	ErrWrongType                  = errors.New("wrong type for dependency")
+)
@@ -0,0 +1,135 @@
+package container
+
+
+import (
+	"testing"
+
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"reflect"
+)
+
+
+func TestErrorsIs(t *testing.T) {
+
+	tests := []struct{
+		Err      error
+		Expected error
+	}{
+		{
+			Err:      newAlreadyRegisteredComplainer("apple"),
+			Expected: ErrAlreadyRegistered,
+		},
+		{
+			Err:      newDependenciesNotFoundComplainer("banana"),
+			Expected: ErrNotFound,
+		},
+		{
+			Err:      newWrongTypeComplainer("cherry", ".Cherry"),
+			Expected: ErrWrongType,
+		},
+		{
+			Err:      newCycleComplainer("apple", "banana", "apple"),
+			Expected: ErrCycle,
+		},
+		{
+			Err:      newClosedComplainer("date"),
+			Expected: ErrClosed,
+		},
+		{
+			Err:      fmt.Errorf("wrapped: %w", newDependenciesNotFoundComplainer("banana")),
+			Expected: ErrNotFound,
+		},
+		{
+			Err:      newInjectionComplainer(newDependenciesNotFoundComplainer("banana"), newWrongTypeComplainer("cherry", ".Cherry")),
+			Expected: ErrNotFound,
+		},
+		{
+			Err:      newInjectionComplainer(newDependenciesNotFoundComplainer("banana"), newWrongTypeComplainer("cherry", ".Cherry")),
+			Expected: ErrWrongType,
+		},
+	}
+
+
+	for testNumber, test := range tests {
+		if !errors.Is(test.Err, test.Expected) {
+			t.Errorf("For test #%d, expected errors.Is(%q, %q) to be true, but it was false.", testNumber, test.Err, test.Expected)
+			continue
+		}
+	}
+}
+
+
+func TestErrorsIsProblemInjectingDependency(t *testing.T) {
+
+	cause := errors.New("apple banana cherry")
+
+	err := newProblemInjectingDependencyComplainer("fruit", ".Fruit", reflect.ValueOf("fruit"), cause)
+
+	if !errors.Is(err, ErrProblemInjectingDependency) {
+		t.Errorf("Expected errors.Is(err, ErrProblemInjectingDependency) to be true, but it was false.")
+		return
+	}
+
+	if !errors.Is(err, cause) {
+		t.Errorf("Expected errors.Is(err, cause) to be true, but it was false.")
+		return
+	}
+
+	if expected, actual := cause, errors.Unwrap(err); expected != actual {
+		t.Errorf("Expected errors.Unwrap to return %v, but actually returned %v.", expected, actual)
+		return
+	}
+}
+
+
+func TestInjectWithMissingAndWrongType(t *testing.T) {
+
+	type Thing struct {
+		Missing   string `inject:"missing"`
+		WrongType int    `inject:"logger"`
+	}
+
+	container := New()
+
+	container.Register("logger", log.New(ioutil.Discard, "we be logging: ", log.Lshortfile))
+
+	err := container.Inject(new(Thing))
+	if nil == err {
+		t.Errorf("Expected Inject to return an error, but it didn't.")
+		return
+	}
+
+	complainer, ok := err.(InjectionComplainer)
+	if !ok {
+		t.Errorf("Expected the error to fit the InjectionComplainer interface, but it didn't. Error: (%T) %q", err, err)
+		return
+	}
+
+	if expected, actual := 2, len(complainer.Errs()); expected != actual {
+		t.Errorf("Expected %d errors, but actually got %d. Errors: %q", expected, actual, complainer.Errs())
+		return
+	}
+
+	var notFound DependenciesNotFoundComplainer
+	if !errors.As(err, &notFound) {
+		t.Errorf("Expected errors.As to find a DependenciesNotFoundComplainer, but it didn't.")
+		return
+	}
+	if expected, actual := []string{"missing"}, notFound.MissingDependencyNames(); 1 != len(actual) || expected[0] != actual[0] {
+		t.Errorf("Expected missing dependency names %q, but actually got %q.", expected, actual)
+		return
+	}
+
+	var wrongType WrongTypeComplainer
+	if !errors.As(err, &wrongType) {
+		t.Errorf("Expected errors.As to find a WrongTypeComplainer, but it didn't.")
+		return
+	}
+	if expected, actual := "logger", wrongType.DependencyName(); expected != actual {
+		t.Errorf("Expected dependency name %q, but actually got %q.", expected, actual)
+		return
+	}
+}
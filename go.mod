@@ -0,0 +1,3 @@
+module github.com/reiver/go-container
+
+go 1.21
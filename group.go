@@ -0,0 +1,187 @@
+package container
+
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+
+// groupMember is a dependency registered with RegisterInGroup (in which case
+// it has no name) or RegisterNamedInGroup.
+type groupMember struct {
+	name string
+	named bool
+	dependency interface{}
+}
+
+
+// RegisterInGroup adds a dependency to a group.
+//
+// Several (different) packages can each add to the same group. And then all the
+// members of the group can be injected, together, into a slice. For example:
+//
+//	type Server struct {
+//		Routes []Route `inject:"group:http-routes"`
+//	}
+//
+// The members are in the order they were added to the group. (If the group is
+// being injected through a scope, then the members added to the scope's ancestors
+// come first.)
+//
+// If nothing has been added to the group, then an empty slice (or map) is injected.
+//
+// To inject a group into a map, use RegisterNamedInGroup instead.
+func (container *internalContainer) RegisterInGroup(groupName string, dependency interface{}) error {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] RegisterInGroup(%q, <dependency> %T)", groupName, dependency)
+
+	member := groupMember{
+		dependency:dependency,
+	}
+
+	if err := container.registerInGroup(groupName, member); nil != err {
+		logger.Printf("[END]   RegisterInGroup(%q, <dependency> %T) with ERROR: %q", groupName, dependency, err)
+		return err
+	}
+
+	logger.Printf("[END]   RegisterInGroup(%q, <dependency> %T)", groupName, dependency)
+
+	return nil
+}
+
+
+// RegisterNamedInGroup adds a dependency, with a name, to a group.
+//
+// This is like RegisterInGroup, except that (since each member has a name) the
+// group can also be injected into a map (with string keys). For example:
+//
+//	type PluginLoader struct {
+//		Plugins map[string]Plugin `inject:"group:plugins"`
+//	}
+//
+// If the group already has a member with that name, then an AlreadyRegisteredComplainer
+// is returned.
+func (container *internalContainer) RegisterNamedInGroup(groupName string, memberName string, dependency interface{}) error {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] RegisterNamedInGroup(%q, %q, <dependency> %T)", groupName, memberName, dependency)
+
+	member := groupMember{
+		name:memberName,
+		named:true,
+		dependency:dependency,
+	}
+
+	if err := container.registerInGroup(groupName, member); nil != err {
+		logger.Printf("[END]   RegisterNamedInGroup(%q, %q, <dependency> %T) with ERROR: %q", groupName, memberName, dependency, err)
+		return err
+	}
+
+	logger.Printf("[END]   RegisterNamedInGroup(%q, %q, <dependency> %T)", groupName, memberName, dependency)
+
+	return nil
+}
+
+func (container *internalContainer) registerInGroup(groupName string, member groupMember) error {
+	groupName = container.qualify(groupName)
+
+	var existing []groupMember
+	if nil != container.parent {
+		existing = container.parent.groupMembers(groupName)
+	}
+
+	container.mutex.Lock()
+	defer container.mutex.Unlock()
+
+	if nil != container.sealed.Load() {
+		return newSealedComplainer(groupTagPrefix + groupName)
+	}
+
+	if member.named {
+		existing = append(existing, container.groups[groupName]...)
+
+		for _, other := range existing {
+			if other.named && member.name == other.name {
+				return newAlreadyRegisteredComplainer(groupTagPrefix + groupName + "[" + strconv.Quote(member.name) + "]")
+			}
+		}
+	}
+
+	container.groups[groupName] = append(container.groups[groupName], member)
+
+	return nil
+}
+
+
+// groupMembers returns all the members of the group, from this container's ancestors
+// (if it is a scope) and from this container.
+func (container *internalContainer) groupMembers(groupName string) []groupMember {
+	var members []groupMember
+
+	if nil != container.parent {
+		members = container.parent.groupMembers(groupName)
+	}
+
+	if snapshot := container.snapshot(); nil != snapshot {
+		return append(members, snapshot.groups[groupName]...)
+	}
+
+	container.mutex.RLock()
+	members = append(members, container.groups[groupName]...)
+	container.mutex.RUnlock()
+
+	return members
+}
+
+
+// resolveGroup returns all the members of the group, as a slice or map of the fieldType.
+//
+// If the group has no members, then it returns an empty slice or map. (Since packages add to
+// groups as they see fit, a group with nothing in it is not a problem.)
+func (container *internalContainer) resolveGroup(tag injectTag, fieldType reflect.Type, fieldPath string) (dependency interface{}, ok bool, err error) {
+	members := container.groupMembers(tag.name)
+
+	switch fieldType.Kind() {
+	case reflect.Slice:
+		slice := reflect.MakeSlice(fieldType, 0, len(members))
+		for _, member := range members {
+			value := reflect.ValueOf(member.dependency)
+			if !value.IsValid() || !value.Type().AssignableTo(fieldType.Elem()) {
+				return nil, true, newWrongTypeComplainer(tag.raw, fieldPath)
+			}
+
+			slice = reflect.Append(slice, value)
+		}
+
+		return slice.Interface(), true, nil
+
+	case reflect.Map:
+		if reflect.String != fieldType.Key().Kind() {
+			return nil, true, newWrongTypeComplainer(tag.raw, fieldPath)
+		}
+
+		m := reflect.MakeMapWithSize(fieldType, len(members))
+		for _, member := range members {
+			if !member.named {
+				return nil, true, fmt.Errorf("group %q has a member without a name (added with RegisterInGroup) so it cannot be injected into a map", tag.name)
+			}
+
+			value := reflect.ValueOf(member.dependency)
+			if !value.IsValid() || !value.Type().AssignableTo(fieldType.Elem()) {
+				return nil, true, newWrongTypeComplainer(tag.raw, fieldPath)
+			}
+
+			m.SetMapIndex(reflect.ValueOf(member.name).Convert(fieldType.Key()), value)
+		}
+
+		return m.Interface(), true, nil
+
+	default:
+		return nil, true, newWrongTypeComplainer(tag.raw, fieldPath)
+	}
+}
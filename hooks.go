@@ -0,0 +1,50 @@
+package container
+
+
+// AfterInjecter provides the AfterInject method.
+//
+// The AfterInjecter interface comes into play with the Container's
+// Inject method.
+//
+// Once the Container's Inject method has (successfully) injected all
+// the dependencies into something that fits an AfterInjecter, it calls
+// its AfterInject method. This is a place for a struct to check its
+// dependencies, or to work out things from them. For example:
+//
+//	type Cherry struct {
+//		Config *Config `inject:"config"`
+//		timeout time.Duration
+//	}
+//	
+//	func (c *Cherry) AfterInject() error {
+//		if 0 >= c.Config.TimeoutSeconds {
+//			return errors.New("the timeout needs to be positive")
+//		}
+//	
+//		c.timeout = time.Duration(c.Config.TimeoutSeconds) * time.Second
+//		return nil
+//	}
+//
+// If what the Dependencies method (see Depender) returns fits an AfterInjecter,
+// then its AfterInject method is called too. (Before the AfterInject method of
+// the thing itself.)
+//
+// If the AfterInject method returns an error, then the Container's Inject method
+// returns it, wrapped in an AfterInjectComplainer.
+type AfterInjecter interface {
+	AfterInject() error
+}
+
+
+// BeforeDestroyer provides the BeforeDestroy method.
+//
+// The BeforeDestroyer interface comes into play with the Container's
+// (and the Scope's) Close method.
+//
+// When a container (or scope) is closed, the BeforeDestroy method is called
+// for each of the dependencies the container constructed (see the Close method)
+// that fits a BeforeDestroyer. (It is called right before the Close method, if
+// the dependency also fits io.Closer.)
+type BeforeDestroyer interface {
+	BeforeDestroy() error
+}
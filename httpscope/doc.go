@@ -0,0 +1,44 @@
+/*
+Package httpscope provides request-scoped 'dependency injection containers' for net/http.
+
+For each HTTP request, a new scope is created from the application's container
+(see container.Container's NewScope method). Registered with that scope are:
+
+	"http.request"         -- the *http.Request
+	"http.response-writer" -- the http.ResponseWriter
+	"http.context"         -- the request's context.Context
+
+The scope is also stored in the request's context.Context, and can be gotten
+from it with the FromContext func.
+
+When the handler returns, the scope is closed. (Which closes any scoped
+dependencies the scope constructed. See container.Container's RegisterScoped
+method.)
+
+For example:
+
+	type helloHandlerDependencies struct {
+		Request *http.Request       `inject:"http.request"`
+		Writer   http.ResponseWriter `inject:"http.response-writer"`
+		Logger  *log.Logger         `inject:"logger"`
+	}
+	
+	type HelloHandler struct {
+		dependencies helloHandlerDependencies
+	}
+	
+	func (h *HelloHandler) Dependencies() interface{} {
+		return &h.dependencies
+	}
+	
+	func (h *HelloHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+		//@TODO
+	}
+	
+	// ...
+	
+	http.Handle("/hello", httpscope.Handler(Container, func() http.Handler {
+		return new(HelloHandler)
+	}))
+*/
+package httpscope
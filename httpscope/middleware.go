@@ -0,0 +1,103 @@
+package httpscope
+
+
+import (
+	"github.com/reiver/go-container"
+
+	"context"
+	"log"
+	"net/http"
+)
+
+
+type contextKey struct{}
+
+
+// FromContext returns the request's scope that Middleware (or Handler) stored
+// in the context.Context.
+func FromContext(ctx context.Context) (container.Scope, bool) {
+	scope, ok := ctx.Value(contextKey{}).(container.Scope)
+
+	return scope, ok
+}
+
+
+// Middleware returns an http.Handler that, for each request, creates a new scope
+// from the container, registers the request, the response writer and the request's
+// context.Context with it, stores it in the request's context.Context, calls the
+// next http.Handler, and then closes the scope.
+//
+// The next http.Handler can get the scope with the FromContext func.
+func Middleware(c container.Container, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope := c.NewScope()
+		defer func() {
+			if err := scope.Close(); nil != err {
+				logf(r, "httpscope: problem closing scope: %v", err)
+			}
+		}()
+
+		ctx := context.WithValue(r.Context(), contextKey{}, scope)
+		r = r.WithContext(ctx)
+
+		if err := register(scope, w, r); nil != err {
+			logf(r, "httpscope: problem registering with scope: %v", err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+
+// Handler returns an http.Handler that, for each request, creates a new http.Handler
+// with newHandler, injects its dependencies from the request's scope, and then calls it.
+//
+// (The request's scope is set up the same way Middleware does it.)
+//
+// If the injection fails, then the error is logged and the client gets a
+// "500 Internal Server Error".
+func Handler(c container.Container, newHandler func() http.Handler) http.Handler {
+	return Middleware(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope, _ := FromContext(r.Context())
+
+		handler := newHandler()
+
+		if err := scope.InjectContext(r.Context(), handler); nil != err {
+			logf(r, "httpscope: problem injecting %T: %v", handler, err)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	}))
+}
+
+
+func register(scope container.Scope, w http.ResponseWriter, r *http.Request) error {
+	if err := scope.Register(RequestName, r); nil != err {
+		return err
+	}
+
+	if err := scope.Register(ResponseWriterName, w); nil != err {
+		return err
+	}
+
+	if err := scope.Register(ContextName, r.Context()); nil != err {
+		return err
+	}
+
+	return nil
+}
+
+
+// logf logs to the http.Server's ErrorLog (if it has one), the same way net/http does.
+func logf(r *http.Request, format string, a ...interface{}) {
+	if server, ok := r.Context().Value(http.ServerContextKey).(*http.Server); ok && nil != server.ErrorLog {
+		server.ErrorLog.Printf(format, a...)
+		return
+	}
+
+	log.Printf(format, a...)
+}
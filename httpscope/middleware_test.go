@@ -0,0 +1,120 @@
+package httpscope
+
+
+import (
+	"github.com/reiver/go-container"
+
+	"testing"
+
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+)
+
+
+type unitOfWork_TestHandler struct {
+	closed bool
+}
+
+func (u *unitOfWork_TestHandler) Close() error {
+	u.closed = true
+	return nil
+}
+
+
+type handlerDependencies_TestHandler struct {
+	Request    *http.Request           `inject:"http.request"`
+	Writer      http.ResponseWriter    `inject:"http.response-writer"`
+	Greeting    string                 `inject:"greeting"`
+	UnitOfWork *unitOfWork_TestHandler `inject:"unit-of-work"`
+}
+
+type handler_TestHandler struct {
+	dependencies handlerDependencies_TestHandler
+}
+
+func (h *handler_TestHandler) Dependencies() interface{} {
+	return &h.dependencies
+}
+
+func (h *handler_TestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(h.dependencies.Writer, "%s %s", h.dependencies.Greeting, h.dependencies.Request.URL.Path)
+}
+
+
+func TestHandler(t *testing.T) {
+
+	var unitsOfWork []*unitOfWork_TestHandler
+
+	c := container.New()
+	c.Register("greeting", "Hello")
+	c.RegisterScoped("unit-of-work", func(ctx context.Context) (interface{}, error) {
+		u := new(unitOfWork_TestHandler)
+		unitsOfWork = append(unitsOfWork, u)
+		return u, nil
+	})
+
+	handler := Handler(c, func() http.Handler {
+		return new(handler_TestHandler)
+	})
+
+	for _, path := range []string{"/apple", "/banana"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", path, nil)
+
+		handler.ServeHTTP(w, r)
+
+		if expected, actual := "Hello "+path, w.Body.String(); expected != actual {
+			t.Errorf("Expected %q, but actually got %q.", expected, actual)
+			return
+		}
+	}
+
+	if expected, actual := 2, len(unitsOfWork); expected != actual {
+		t.Errorf("Expected %d units of work, but actually got %d.", expected, actual)
+		return
+	}
+
+	for i, u := range unitsOfWork {
+		if !u.closed {
+			t.Errorf("Expected unit of work #%d to have been closed, but it wasn't.", i)
+			return
+		}
+	}
+}
+
+
+func TestMiddleware(t *testing.T) {
+
+	c := container.New()
+
+	var ok bool
+	var request interface{}
+	var ctx interface{}
+
+	handler := Middleware(c, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var scope container.Scope
+		scope, ok = FromContext(r.Context())
+		if !ok {
+			return
+		}
+
+		request, _ = scope.Get(RequestName)
+		ctx, _ = scope.Get(ContextName)
+
+		if request != r {
+			ok = false
+		}
+		if ctx != r.Context() {
+			ok = false
+		}
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+
+	if !ok {
+		t.Errorf("Expected the scope to be in the context.Context with the request and context.Context registered, but it wasn't. Request: %v. Context: %v.", request, ctx)
+		return
+	}
+}
@@ -0,0 +1,9 @@
+package httpscope
+
+
+// These are the names that things are registered with, in each request's scope.
+const (
+	RequestName        = "http.request"
+	ResponseWriterName = "http.response-writer"
+	ContextName        = "http.context"
+)
@@ -0,0 +1,75 @@
+package container
+
+
+import (
+	"strings"
+)
+
+
+// groupTagPrefix is what an `inject` struct tag begins with, when what is to be
+// injected is all the members of a group. As in:
+//
+//	Routes []Route `inject:"group:http-routes"`
+const groupTagPrefix = "group:"
+
+// prefixTagPrefix is what an `inject` struct tag begins with, when what is to be
+// injected is everything registered with a name that begins with a prefix. As in:
+//
+//	DBs map[string]*sql.DB `inject:"prefix:db."`
+const prefixTagPrefix = "prefix:"
+
+// methodTagOption is the option of an `inject` struct tag that says which method of
+// the dependency to inject into a func field. As in:
+//
+//	Handle func(Event) error `inject:"event-handler,method=Handle"`
+const methodTagOption = "method="
+
+
+// injectTag is the parsed form of an `inject` struct tag.
+type injectTag struct {
+	// raw is the value of the `inject` struct tag, without any options.
+	raw string
+	name string
+	group bool
+	prefix bool
+
+	// self is whether the `inject` struct tag is `inject:"@container"` (see Resolver).
+	self bool
+
+	// method is the name of the method (from a "method=" option) of the dependency
+	// that is to be injected (adapted into a func), rather than the dependency itself.
+	method string
+}
+
+
+// parseInjectTag parses the value of an `inject` struct tag.
+func parseInjectTag(value string) injectTag {
+	var options string
+	if index := strings.IndexByte(value, ','); 0 <= index {
+		value, options = value[:index], value[index+1:]
+	}
+
+	tag := injectTag{
+		raw:value,
+		name:value,
+	}
+
+	switch {
+	case containerTag == value:
+		tag.self = true
+	case strings.HasPrefix(value, groupTagPrefix):
+		tag.name = value[len(groupTagPrefix):]
+		tag.group = true
+	case strings.HasPrefix(value, prefixTagPrefix):
+		tag.name = value[len(prefixTagPrefix):]
+		tag.prefix = true
+	}
+
+	for _, option := range strings.Split(options, ",") {
+		if strings.HasPrefix(option, methodTagOption) {
+			tag.method = option[len(methodTagOption):]
+		}
+	}
+
+	return tag
+}
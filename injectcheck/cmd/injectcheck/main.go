@@ -0,0 +1,20 @@
+/*
+Command injectcheck checks `inject` struct tags. It is meant to be used with go vet, as in:
+
+	go vet -vettool=$(which injectcheck) ./...
+
+See the injectcheck package for what it checks.
+*/
+package main
+
+
+import (
+	"github.com/reiver/go-container/injectcheck"
+
+	"golang.org/x/tools/go/analysis/unitchecker"
+)
+
+
+func main() {
+	unitchecker.Main(injectcheck.Analyzer)
+}
@@ -0,0 +1,415 @@
+/*
+Package injectcheck provides a go/analysis Analyzer that checks `inject` struct tags.
+
+It reports:
+
+  - `inject` struct tags on unexported fields (which cannot be injected into).
+  - malformed `inject` struct tags (such as `inject:""` or `inject:"group:"`, or a "group:"
+    or "prefix:" struct tag on a field that is not a slice or map, or a "method=" option
+    on a field that is not a func, or a name that begins with "@" other than "@container").
+  - the same name being in more than one `inject` struct tag of a single struct.
+  - Dependencies methods (see container.Depender) that return a struct rather than a
+    pointer to one (in which case what is injected gets lost).
+  - fields whose type cannot be assigned the dependency registered with the name in their
+    `inject` struct tag, when that dependency is registered (with Register) in the same
+    package, and is not decorated (see container.Container's Decorate method). (Fields of
+    func and chan types are not checked, since the dependency might be adapted to them. Except
+    that a dependency that is not a func needs a "method=" option for a func field. And
+    a container.Lazy[T] or container.Provider[T] field is checked as if it were a T field.)
+
+It can be used with go vet, via the cmd/injectcheck command:
+
+	go install github.com/reiver/go-container/injectcheck/cmd/injectcheck
+	go vet -vettool=$(which injectcheck) ./...
+
+It is a module of its own (github.com/reiver/go-container/injectcheck), separate from the
+github.com/reiver/go-container module, so that using the container does not need what
+injectcheck needs (golang.org/x/tools, and a newer version of Go).
+*/
+package injectcheck
+
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+
+const containerImportPath = "github.com/reiver/go-container"
+
+
+// Analyzer checks `inject` struct tags.
+var Analyzer = &analysis.Analyzer{
+	Name: "injectcheck",
+	Doc:  "check `inject` struct tags (of github.com/reiver/go-container)",
+	Run:  run,
+}
+
+
+// registered is a dependency registered (with Register) in the package being checked.
+type registered struct {
+	pos token.Pos
+	typ types.Type
+}
+
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	registrations := registrationsOf(pass)
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.StructType:
+				checkStruct(pass, node, registrations)
+			case *ast.FuncDecl:
+				checkDependencies(pass, node)
+			}
+
+			return true
+		})
+	}
+
+	return nil, nil
+}
+
+
+// checkStruct checks the `inject` struct tags of the struct.
+func checkStruct(pass *analysis.Pass, structType *ast.StructType, registrations map[string]registered) {
+	seen := map[string]string{}
+
+	for _, field := range structType.Fields.List {
+		if nil == field.Tag {
+			continue
+		}
+
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if nil != err {
+			continue
+		}
+
+		value, ok := reflect.StructTag(tag).Lookup("inject")
+		if !ok {
+			continue
+		}
+
+		names := field.Names
+		if 0 >= len(names) {
+			names = []*ast.Ident{ast.NewIdent(embeddedName(field.Type))}
+		}
+
+		// A container.Lazy[T] or container.Provider[T] field is checked as if it were a T field.
+		fieldType := elemType(pass.TypesInfo.TypeOf(field.Type))
+
+		if problem := checkTag(value, fieldType); "" != problem {
+			pass.Reportf(field.Tag.Pos(), "malformed inject struct tag %q: %s", value, problem)
+			continue
+		}
+
+		// What follows is mostly about the name, not the options.
+		value, options, _ := strings.Cut(value, ",")
+
+		for _, name := range names {
+			if !ast.IsExported(name.Name) {
+				pass.Reportf(field.Pos(), "inject struct tag on unexported field %s: it cannot be injected into (export it, or see container.Depender)", name.Name)
+			}
+
+			if other, ok := seen[value]; ok {
+				pass.Reportf(field.Pos(), "%q is already injected into field %s of this struct", value, other)
+			} else {
+				seen[value] = name.Name
+			}
+		}
+
+		reg, ok := registrations[value]
+		if !ok || nil == fieldType {
+			continue
+		}
+		switch fieldType.Underlying().(type) {
+		case *types.Chan:
+			continue
+		case *types.Signature:
+			// A method of a dependency that is not a func is only injected if it is given.
+			if _, isFunc := reg.typ.Underlying().(*types.Signature); !isFunc && "" == options {
+				pass.Reportf(field.Pos(), "dependency %q is registered (at %s) as a %s, which is not a func: give the method to inject into a field of type %s with a \"method=\" option", value, pass.Fset.Position(reg.pos), reg.typ, fieldType)
+			}
+			continue
+		}
+		if !types.AssignableTo(reg.typ, fieldType) {
+			pass.Reportf(field.Pos(), "dependency %q is registered (at %s) as a %s, which cannot be injected into a field of type %s", value, pass.Fset.Position(reg.pos), reg.typ, fieldType)
+		}
+	}
+}
+
+
+// checkTag returns what is wrong with the value of the `inject` struct tag, or "" (empty string)
+// if nothing is wrong with it.
+func checkTag(value string, fieldType types.Type) string {
+	value, options, _ := strings.Cut(value, ",")
+
+	if "" == value {
+		return "the name is empty"
+	}
+
+	if problem := checkTagOptions(value, options, fieldType); "" != problem {
+		return problem
+	}
+
+	// Names that begin with "@" are reserved, and "@container" is the only one there is.
+	if strings.HasPrefix(value, "@") && "@container" != value {
+		return "unknown name (names that begin with \"@\" are reserved, and \"@container\" is the only one)"
+	}
+	if strings.TrimSpace(value) != value {
+		return "the name begins or ends with a space"
+	}
+
+	for _, prefix := range []string{"group:", "prefix:"} {
+		if !strings.HasPrefix(value, prefix) {
+			continue
+		}
+
+		if prefix == value && "group:" == prefix {
+			return "the group name is empty"
+		}
+
+		if nil == fieldType {
+			return ""
+		}
+
+		switch fieldType.Underlying().(type) {
+		case *types.Map:
+			return ""
+		case *types.Slice:
+			if "group:" == prefix {
+				return ""
+			}
+			return "a \"prefix:\" struct tag is only for a map field"
+		default:
+			return "a \"" + prefix + "\" struct tag is only for a slice or map field"
+		}
+	}
+
+	return ""
+}
+
+
+// checkTagOptions returns what is wrong with the options (what comes after the first comma) of the
+// `inject` struct tag, or "" (empty string) if nothing is wrong with them.
+func checkTagOptions(value string, options string, fieldType types.Type) string {
+	if "" == options {
+		return ""
+	}
+
+	for _, option := range strings.Split(options, ",") {
+		if !strings.HasPrefix(option, "method=") {
+			return "unknown option " + strconv.Quote(option)
+		}
+
+		if "method=" == option {
+			return "the method name is empty"
+		}
+		if strings.HasPrefix(value, "group:") || strings.HasPrefix(value, "prefix:") {
+			return "a \"method=\" option cannot be used with a \"group:\" or \"prefix:\" struct tag"
+		}
+		if nil == fieldType {
+			continue
+		}
+		if _, ok := fieldType.Underlying().(*types.Signature); !ok {
+			return "a \"method=\" option is only for a func field"
+		}
+	}
+
+	return ""
+}
+
+
+// checkDependencies checks that, if the func is a Dependencies method (see container.Depender),
+// it does not return a struct (rather than a pointer to one).
+func checkDependencies(pass *analysis.Pass, funcDecl *ast.FuncDecl) {
+	if nil == funcDecl.Recv || "Dependencies" != funcDecl.Name.Name || nil == funcDecl.Body {
+		return
+	}
+	if 0 < funcDecl.Type.Params.NumFields() || nil == funcDecl.Type.Results || 1 != funcDecl.Type.Results.NumFields() {
+		return
+	}
+
+	ast.Inspect(funcDecl.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if 1 != len(node.Results) {
+				return true
+			}
+
+			typ := pass.TypesInfo.TypeOf(node.Results[0])
+			if nil == typ {
+				return true
+			}
+
+			if _, ok := typ.Underlying().(*types.Struct); ok {
+				pass.Reportf(node.Results[0].Pos(), "Dependencies returns a %s rather than a pointer to it, so what is injected into it is lost", typ)
+			}
+		}
+
+		return true
+	})
+}
+
+
+// registrationsOf returns the dependencies registered (with Register) in the package, keyed
+// by name, where both the name and the type of the dependency can be figured out.
+//
+// If the same name is registered (or replaced) more than once (for example, once in main and
+// once in a test), or is decorated, then it is skipped.
+func registrationsOf(pass *analysis.Pass) map[string]registered {
+	registrations := map[string]registered{}
+	skipped := map[string]struct{}{}
+
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || 2 != len(call.Args) {
+				return true
+			}
+
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || !isContainerMethod(pass, selector) {
+				return true
+			}
+
+			name, ok := stringConstant(pass, call.Args[0])
+			if !ok {
+				return true
+			}
+			name = namespacePrefix(pass, selector.X) + name
+
+			switch selector.Sel.Name {
+			case "Register", "Replace":
+				// Nothing here.
+			case "Decorate":
+				skipped[name] = struct{}{}
+				return true
+			default:
+				return true
+			}
+
+			typ := pass.TypesInfo.TypeOf(call.Args[1])
+			if nil == typ || types.IsInterface(typ) {
+				return true
+			}
+
+			if _, ok := registrations[name]; ok {
+				skipped[name] = struct{}{}
+				return true
+			}
+
+			registrations[name] = registered{
+				pos:call.Pos(),
+				typ:typ,
+			}
+
+			return true
+		})
+	}
+
+	for name := range skipped {
+		delete(registrations, name)
+	}
+
+	return registrations
+}
+
+
+// elemType returns the T, if the type is a container.Lazy[T] or a container.Provider[T]. Else it
+// returns the type itself.
+func elemType(typ types.Type) types.Type {
+	named, ok := typ.(*types.Named)
+	if !ok || 1 != named.TypeArgs().Len() {
+		return typ
+	}
+
+	obj := named.Obj()
+	if nil == obj.Pkg() || containerImportPath != obj.Pkg().Path() {
+		return typ
+	}
+
+	switch obj.Name() {
+	case "Lazy", "Provider":
+		return named.TypeArgs().At(0)
+	default:
+		return typ
+	}
+}
+
+
+// isContainerMethod returns whether the selector is of a method of (something from) the
+// github.com/reiver/go-container package.
+func isContainerMethod(pass *analysis.Pass, selector *ast.SelectorExpr) bool {
+	selection, ok := pass.TypesInfo.Selections[selector]
+	if !ok {
+		return false
+	}
+
+	pkg := selection.Obj().Pkg()
+
+	return nil != pkg && containerImportPath == pkg.Path()
+}
+
+
+// namespacePrefix returns the prefix, if the expression is something like:
+//
+//	Container.Namespace("kitchen.")
+//
+// Else "" (empty string) is returned.
+func namespacePrefix(pass *analysis.Pass, expr ast.Expr) string {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || 1 != len(call.Args) {
+		return ""
+	}
+
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || "Namespace" != selector.Sel.Name || !isContainerMethod(pass, selector) {
+		return ""
+	}
+
+	prefix, _ := stringConstant(pass, call.Args[0])
+
+	return namespacePrefix(pass, selector.X) + prefix
+}
+
+
+// stringConstant returns the value of the expression, if it is a constant string.
+func stringConstant(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || nil == tv.Value {
+		return "", false
+	}
+
+	s, err := strconv.Unquote(tv.Value.ExactString())
+	if nil != err {
+		return "", false
+	}
+
+	return s, true
+}
+
+
+// embeddedName returns the field name of an embedded field of the type.
+func embeddedName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,15 @@
+package injectcheck
+
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+
+// TestAnalyzer checks the packages in the testdata directory. (There, the
+// github.com/reiver/go-container package is a stand-in for the real one.)
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "billing")
+}
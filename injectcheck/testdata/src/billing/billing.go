@@ -0,0 +1,79 @@
+// Package billing is checked by the injectcheck Analyzer. Each line with a "want" comment
+// is expected to get a diagnostic that matches the (regular expression) in the comment.
+package billing
+
+import (
+	"io"
+	"strings"
+
+	"github.com/reiver/go-container"
+)
+
+type Service struct {
+	Logger io.Writer          `inject:"logger"`
+	Name   string             `inject:"name"`
+	Port   string             `inject:"port"` // want "registered .* as a int, which cannot be injected into a field of type string"
+	Token  int                `inject:"token"`
+	Fruit  int                `inject:"kitchen.fruit"` // want "registered .* as a string"
+	db     io.Reader          `inject:"db"` // want "unexported field db"
+	Other  io.Writer          `inject:"logger"` // want "already injected into field Logger"
+	Empty  string             `inject:""` // want "malformed .* the name is empty"
+	Routes []string           `inject:"group:"` // want "the group name is empty"
+	Route  string             `inject:"group:routes"` // want "only for a slice or map field"
+	DBs    []string           `inject:"prefix:db."` // want "only for a map field"
+	All    map[string]string  `inject:"prefix:"`
+	Handle func(string) error `inject:"handler,method=Handle"`
+	Bad    string             `inject:"handler,method=Handle"` // want "only for a func field"
+	Odd    func()             `inject:"handler,omitempty"` // want "unknown option"
+	Self   interface{}        `inject:"@container"`
+	Scope  interface{}        `inject:"@scope"` // want "unknown name"
+}
+
+type Formatter struct {
+	Replace func(string) string `inject:"replacer,method=Replace"`
+	Upper   func(string) string `inject:"upper"`
+}
+
+type Unformatter struct {
+	Replace func(string) string `inject:"replacer"` // want "which is not a func: give the method"
+}
+
+type Lazily struct {
+	Name container.Lazy[string] `inject:"name"`
+	Port container.Lazy[string] `inject:"port"` // want "registered .* as a int, which cannot be injected into a field of type string"
+}
+
+type Provided struct {
+	Name container.Provider[string] `inject:"name"`
+	Port container.Provider[string] `inject:"port"` // want "registered .* as a int, which cannot be injected into a field of type string"
+}
+
+type serviceDependencies struct {
+	Name string `inject:"name"`
+}
+
+type Hidden struct {
+	dependencies serviceDependencies
+}
+
+func (h Hidden) Dependencies() interface{} {
+	return h.dependencies // want "returns a billing.serviceDependencies rather than a pointer"
+}
+
+type Shown struct {
+	dependencies serviceDependencies
+}
+
+func (s *Shown) Dependencies() interface{} {
+	return &s.dependencies
+}
+
+func register(c container.Container) {
+	c.Register("name", "billing")
+	c.Register("port", 8080)
+	c.Register("token", "abc")
+	c.Decorate("token", nil)
+	c.Namespace("kitchen.").Register("fruit", "apple")
+	c.Register("replacer", strings.NewReplacer("a", "b"))
+	c.Register("upper", strings.ToUpper)
+}
@@ -0,0 +1,15 @@
+// Package container stands in for the github.com/reiver/go-container package, for the tests
+// of the injectcheck package.
+package container
+
+type Container interface {
+	Register(string, interface{}) error
+	Decorate(string, func(interface{}) (interface{}, error)) error
+	Namespace(string) Container
+}
+
+type Lazy[T any] struct {
+	handle *T
+}
+
+type Provider[T any] func() (T, error)
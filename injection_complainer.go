@@ -0,0 +1,156 @@
+package container
+
+
+import (
+	"bytes"
+	"io"
+)
+
+
+const injectionMessagePrefix = "Problems injecting dependencies"
+
+
+// InjectionComplainer is an 'error' that represents the situation where
+// the 'dependency injection container' ran into more than one problem during
+// a single call to Inject.
+//
+// For example, some dependencies might not have been registered (which would
+// give a DependenciesNotFoundComplainer) and also some other dependency might
+// have been of the wrong type.
+//
+// You can get each of the individual problems by calling the Errs method.
+//
+// The Unwrap method returns the same thing as the Errs method. So errors.Is
+// and errors.As look at each of the individual problems. For example:
+//
+//	if err := Container.Inject(thing); nil != err {
+//		var complainer container.WrongTypeComplainer
+//		if errors.As(err, &complainer) {
+//			//@TODO
+//		}
+//	}
+type InjectionComplainer interface {
+	error
+	InjectionComplainer()
+	Errs() []error
+	Unwrap() []error
+}
+
+// internalInjectionComplainer is the only underlying implementation that fits the
+// InjectionComplainer interface, in this library.
+type internalInjectionComplainer struct {
+	errs []error
+	notFound *internalDependenciesNotFoundComplainer
+}
+
+// newInjectionComplainer creates a new internalInjectionComplainer (struct) and
+// returns it as an InjectionComplainer (interface).
+//
+// Any nil errors are skipped.
+func newInjectionComplainer(errs ...error) InjectionComplainer {
+	complainer := internalInjectionComplainer{}
+
+	for _, err := range errs {
+		complainer.insert(err)
+	}
+
+	return &complainer
+}
+
+
+// Error method is necessary to satisfy the 'error' interface (and the InjectionComplainer
+// interface).
+func (complainer *internalInjectionComplainer) Error() string {
+	var buffer bytes.Buffer
+
+	io.WriteString(&buffer, injectionMessagePrefix)
+	for i, err := range complainer.errs {
+		if 0 == i {
+			io.WriteString(&buffer, ": ")
+		} else {
+			io.WriteString(&buffer, "; ")
+		}
+
+		io.WriteString(&buffer, err.Error())
+	}
+
+	return buffer.String()
+}
+
+
+func (complainer *internalInjectionComplainer) InjectionComplainer() {
+	// Nothing here.
+}
+
+
+// Errs method is necessary to satisfy the 'InjectionComplainer' interface.
+func (complainer *internalInjectionComplainer) Errs() []error {
+	errs := make([]error, len(complainer.errs))
+	copy(errs, complainer.errs)
+
+	return errs
+}
+
+
+// Unwrap returns the same thing as the Errs method.
+//
+// This makes it so errors.Is and errors.As look at each of the individual problems.
+func (complainer *internalInjectionComplainer) Unwrap() []error {
+	return complainer.Errs()
+}
+
+
+// insert is a helper method that adds in another problem.
+//
+// If the other problem is itself an InjectionComplainer, then its individual
+// problems are added (rather than nesting it).
+//
+// All the DependenciesNotFoundComplainers are merged into a single one.
+func (complainer *internalInjectionComplainer) insert(err error) {
+	switch other := err.(type) {
+	case nil:
+		// Nothing here.
+	case InjectionComplainer:
+		for _, e := range other.Errs() {
+			complainer.insert(e)
+		}
+	case DependenciesNotFoundComplainer:
+		if nil == complainer.notFound {
+			complainer.notFound = newDependenciesNotFoundComplainer().(*internalDependenciesNotFoundComplainer)
+			complainer.errs = append(complainer.errs, complainer.notFound)
+		}
+		complainer.notFound.concatenate(other)
+	default:
+		complainer.errs = append(complainer.errs, err)
+	}
+}
+
+
+// err is a helper method that returns what should be returned for these problems.
+//
+// If there are no problems, then nil is returned. If there is only one problem,
+// then that one problem is returned (as is). Else the InjectionComplainer itself
+// is returned.
+func (complainer *internalInjectionComplainer) err() error {
+	switch complainer.len() {
+	case 0:
+		return nil
+	case 1:
+		return complainer.errs[0]
+	default:
+		return complainer
+	}
+}
+
+
+// len is a helper method that returns the count of the number of problems.
+//
+// A nil *internalInjectionComplainer has a length of 0. (So that one does not
+// have to be created until there is a problem.)
+func (complainer *internalInjectionComplainer) len() int {
+	if nil == complainer {
+		return 0
+	}
+
+	return len(complainer.errs)
+}
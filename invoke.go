@@ -0,0 +1,294 @@
+package container
+
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+
+var (
+	contextType  = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+	resolverType = reflect.TypeOf((*Resolver)(nil)).Elem()
+)
+
+
+// Invoke calls the func, with each of its parameters resolved from the container.
+//
+// This makes it so constructors (and other funcs) that take their dependencies as parameters
+// can be used with the container, as is. For example:
+//
+//	results, err := Container.Invoke(billing.NewService)
+//
+// Each parameter is resolved by its type. I.e., the dependency whose type is the same as the
+// type of the parameter is used. (Or, if there isn't one, the dependency whose type is assignable
+// to the type of the parameter.) Only dependencies registered as is (with Register) are looked at,
+// since the types of dependencies registered with RegisterProvider, RegisterScoped, or RegisterTransient
+// are not known until they are provided. Those can be resolved by name (see below).
+//
+// Parameters can instead be resolved by name, by passing the names (in the same order as the
+// parameters) after the func. A name of "" (empty string) means to resolve that parameter by
+// its type. For example:
+//
+//	results, err := Container.Invoke(billing.NewService, "logger", "", "primary-db")
+//
+// A parameter of type context.Context (that isn't given a name) gets the context.Context passed
+// to InvokeContext. (Invoke passes context.Background().) A parameter of type Resolver (that isn't
+// given a name) gets a Resolver for the container. A variadic parameter gets nothing.
+//
+// If a parameter cannot be resolved, then a DependenciesNotFoundComplainer is returned (with the
+// name, or, if the parameter was being resolved by its type, with the type). If more than one
+// dependency is of the type, then an AmbiguousDependencyComplainer is returned. (And if none are,
+// but there are dependencies whose types are not known until they are provided, then an
+// AmbiguousDependencyComplainer with those is returned, rather than guessing.) If a named dependency
+// is of the wrong type, then a WrongTypeComplainer is returned. (Its Path is "" (empty string),
+// since it is not about a struct field. Its error message says which parameter it is about.)
+//
+// What the func returns is returned, except for a last result of type error. If that is not nil,
+// then it is returned as the error.
+func (container *internalContainer) Invoke(fn interface{}, names ...string) ([]interface{}, error) {
+	return container.InvokeContext(context.Background(), fn, names...)
+}
+
+
+func (container *internalContainer) InvokeContext(ctx context.Context, fn interface{}, names ...string) ([]interface{}, error) {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] InvokeContext(%T, %q)", fn, names)
+
+	results, err := container.invoke(ctx, fn, names)
+	if nil != err {
+		logger.Printf("[END]   InvokeContext(%T, %q) with ERROR: %q", fn, names, err)
+		return results, err
+	}
+
+	logger.Printf("[END]   InvokeContext(%T, %q)", fn, names)
+
+	return results, nil
+}
+
+
+// Construct is like Invoke, except that it is for constructors. The func has to return
+// one result, or two results where the second is of type error. For example:
+//
+//	service, err := Container.Construct(billing.NewService)
+//	if nil != err {
+//		//@TODO
+//	}
+func (container *internalContainer) Construct(fn interface{}, names ...string) (interface{}, error) {
+	return container.ConstructContext(context.Background(), fn, names...)
+}
+
+
+func (container *internalContainer) ConstructContext(ctx context.Context, fn interface{}, names ...string) (interface{}, error) {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] ConstructContext(%T, %q)", fn, names)
+
+	fnType := reflect.TypeOf(fn)
+	if nil == fnType || reflect.Func != fnType.Kind() || !(1 == fnType.NumOut() || (2 == fnType.NumOut() && errorType == fnType.Out(1))) {
+		err := fmt.Errorf("Cannot construct with %T. It needs to be a func that returns one result, or two results where the second is an error.", fn)
+
+		logger.Printf("[END]   ConstructContext(%T, %q) with ERROR: %q", fn, names, err)
+		return nil, err
+	}
+
+	results, err := container.invoke(ctx, fn, names)
+	if nil != err {
+		logger.Printf("[END]   ConstructContext(%T, %q) with ERROR: %q", fn, names, err)
+		return nil, err
+	}
+
+	logger.Printf("[END]   ConstructContext(%T, %q)", fn, names)
+
+	return results[0], nil
+}
+
+
+func (container *internalContainer) invoke(ctx context.Context, fn interface{}, names []string) ([]interface{}, error) {
+
+	fnValue := reflect.ValueOf(fn)
+	if reflect.Func != fnValue.Kind() || fnValue.IsNil() {
+		return nil, fmt.Errorf("Cannot invoke %T. It is not a func.", fn)
+	}
+	fnType := fnValue.Type()
+
+	numIn := fnType.NumIn()
+	if fnType.IsVariadic() {
+		numIn--
+	}
+	if len(names) > numIn {
+		return nil, fmt.Errorf("Cannot invoke %s with %d names, since it only has %d parameters (that are not variadic).", fnType, len(names), numIn)
+	}
+
+	funcName := fnType.String()
+	if f := runtime.FuncForPC(fnValue.Pointer()); nil != f {
+		funcName = f.Name()
+	}
+
+	// Initialize.
+	dependenciesNotFoundComplainer := newDependenciesNotFoundComplainer().(*internalDependenciesNotFoundComplainer)
+
+	args := make([]reflect.Value, numIn)
+	for i := range args {
+		paramType := fnType.In(i)
+
+		requiredBy := fmt.Sprintf("%s parameter #%d", funcName, i+1)
+
+		var name string
+		if i < len(names) {
+			name = names[i]
+		}
+
+		if "" == name && contextType == paramType {
+			args[i] = reflect.ValueOf(&ctx).Elem()
+			continue
+		}
+		if "" == name && resolverType == paramType {
+			resolver := container.resolver()
+			args[i] = reflect.ValueOf(&resolver).Elem()
+			continue
+		}
+
+		if "" == name {
+			var err error
+
+			name, err = container.nameForType(paramType, requiredBy)
+			if nil != err {
+				return nil, err
+			}
+			if "" == name {
+				dependenciesNotFoundComplainer.insert(paramType.String(), requiredBy)
+				continue
+			}
+		} else {
+			name = container.qualify(name)
+		}
+
+		dependency, ok, err := container.resolve(ctx, name, requiredBy)
+		if nil != err {
+			return nil, err
+		}
+		if !ok {
+			dependenciesNotFoundComplainer.insert(name, requiredBy)
+			continue
+		}
+
+		value := reflect.ValueOf(dependency)
+		switch {
+		case !value.IsValid():
+			value = reflect.Zero(paramType)
+		case !value.Type().AssignableTo(paramType):
+			adapted, ok := adapt(value, paramType, "")
+			if !ok {
+				return nil, newParameterWrongTypeComplainer(name, requiredBy)
+			}
+			value = adapted
+		}
+
+		args[i] = value
+	}
+
+	if 0 < dependenciesNotFoundComplainer.len() {
+		return nil, dependenciesNotFoundComplainer
+	}
+
+	out := fnValue.Call(args)
+
+	var err error
+	if n := len(out); 0 < n && errorType == fnType.Out(n-1) {
+		if !out[n-1].IsNil() {
+			err = out[n-1].Interface().(error)
+		}
+		out = out[:n-1]
+	}
+
+	results := make([]interface{}, len(out))
+	for i, value := range out {
+		results[i] = value.Interface()
+	}
+
+	return results, err
+}
+
+
+// nameForType returns the name of the dependency to use for something of the type.
+//
+// The dependency whose type is the same as the type is used. Or, if there isn't one, the
+// dependency whose type is assignable to the type. Only dependencies whose type is known from
+// how they were registered are looked at. (See the declaredType method of registration.)
+//
+// If there is no such dependency, then "" (empty string) is returned. Unless there are dependencies
+// whose types are not known (because they are registered with a provider), in which case one of
+// them might be of the type, and so an AmbiguousDependencyComplainer (with them) is returned. If
+// there is more than one such dependency, then an AmbiguousDependencyComplainer is returned too.
+func (container *internalContainer) nameForType(typ reflect.Type, requiredBy string) (string, error) {
+	var same []string
+	var assignable []string
+	var unknown []string
+
+	seen := map[*registration]struct{}{}
+	for _, name := range container.names() {
+		if !strings.HasPrefix(name, container.namespace) {
+			continue
+		}
+		if _, isAlias := container.aliasTarget(name); isAlias {
+			continue
+		}
+
+		reg, ok := container.lookup(name)
+		if !ok {
+			continue
+		}
+		if _, ok := seen[reg]; ok {
+			continue
+		}
+		seen[reg] = struct{}{}
+
+		if nil != reg.provider {
+			unknown = append(unknown, name)
+			continue
+		}
+
+		dependencyType, ok := reg.declaredType()
+		if !ok {
+			continue
+		}
+
+		switch {
+		case typ == dependencyType:
+			same = append(same, name)
+		case dependencyType.AssignableTo(typ):
+			assignable = append(assignable, name)
+		}
+	}
+
+	candidates := same
+	if 0 >= len(candidates) {
+		candidates = assignable
+	}
+
+	switch len(candidates) {
+	case 0:
+		if 0 < len(unknown) {
+			for i, name := range unknown {
+				unknown[i] = container.unqualify(name)
+			}
+			return "", newUnknownTypeComplainer(typ.String(), requiredBy, unknown)
+		}
+		return "", nil
+	case 1:
+		return candidates[0], nil
+	default:
+		for i, candidate := range candidates {
+			candidates[i] = container.unqualify(candidate)
+		}
+		return "", newAmbiguousDependencyComplainer(typ.String(), requiredBy, candidates)
+	}
+}
@@ -0,0 +1,95 @@
+package container
+
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+
+// Lazy is a struct field type for a dependency that is not resolved until it is needed.
+//
+// When a struct field of type Lazy[T] is injected into, the dependency is not resolved. What
+// is injected is a handle, whose Get method resolves the dependency the first time it is called.
+// For example:
+//
+//	type Mailer struct {
+//		Templates container.Lazy[*template.Template] `inject:"email-templates"`
+//	}
+//
+//	func (mailer *Mailer) Send(to string, name string) error {
+//		templates, err := mailer.Templates.Get()
+//		if nil != err {
+//			return err
+//		}
+//
+//		//@TODO
+//	}
+//
+// This is useful for breaking up construction-order problems between services that need each
+// other, and for keeping expensive dependencies from being made until they are actually needed.
+//
+// The dependency is resolved (at most) once, the way sync.Once works. So, if resolving it returns
+// an error, then Get keeps returning that error. (Copies of a Lazy[T] share the same handle, and so
+// resolve the dependency only once between them.)
+//
+// Whether the dependency is registered is still checked when injecting into the struct field (so a
+// DependenciesNotFoundComplainer is still returned by Inject, if it is not).
+//
+// The dependency is resolved from the container that did the injecting, with the context.Context that
+// was passed to InjectContext (except that it being canceled, or its deadline being exceeded, does not
+// matter).
+// If that container (for example, a scope) has been closed by then (see the Close method), then a
+// ClosedComplainer is returned.
+type Lazy[T any] struct {
+	handle *lazyHandle[T]
+}
+
+
+type lazyHandle[T any] struct {
+	once sync.Once
+	resolve func() (interface{}, error)
+	value T
+	err error
+}
+
+
+// Get returns the dependency, resolving it first, if it has not been resolved yet.
+//
+// If the Lazy[T] was never injected into, then an error is returned.
+func (lazy Lazy[T]) Get() (T, error) {
+	if nil == lazy.handle {
+		var nada T
+		return nada, fmt.Errorf("Problem getting lazy dependency: %T has not been injected into", lazy)
+	}
+
+	handle := lazy.handle
+
+	handle.once.Do(func() {
+		dependency, err := handle.resolve()
+		if nil != err {
+			handle.err = err
+			return
+		}
+
+		if nil != dependency {
+			handle.value = dependency.(T)
+		}
+	})
+
+	return handle.value, handle.err
+}
+
+
+// deferredType returns the type of the dependency, T.
+func (lazy *Lazy[T]) deferredType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+
+func (lazy *Lazy[T]) injectDeferred(resolve func() (interface{}, error)) {
+	lazy.handle = &lazyHandle[T]{
+		resolve:resolve,
+	}
+}
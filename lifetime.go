@@ -0,0 +1,43 @@
+package container
+
+
+// Lifetime says how many instances of a registered dependency there are, and
+// when they get constructed.
+type Lifetime int
+
+
+const (
+	// LifetimeSingleton is for dependencies registered with Register.
+	// There is one instance, which was passed to Register.
+	LifetimeSingleton Lifetime = iota
+
+	// LifetimeLazySingleton is for dependencies registered with RegisterProvider.
+	// There is one instance, which gets constructed the first time it is needed.
+	LifetimeLazySingleton
+
+	// LifetimeScoped is for dependencies registered with RegisterScoped.
+	// There is one instance per scope, which gets constructed the first time
+	// it is needed through that scope.
+	LifetimeScoped
+
+	// LifetimeTransient is for dependencies registered with RegisterTransient.
+	// There is a new instance each time it is needed.
+	LifetimeTransient
+)
+
+
+// String returns the name of the Lifetime, as in "singleton".
+func (lifetime Lifetime) String() string {
+	switch lifetime {
+	case LifetimeSingleton:
+		return "singleton"
+	case LifetimeLazySingleton:
+		return "lazy-singleton"
+	case LifetimeScoped:
+		return "scoped"
+	case LifetimeTransient:
+		return "transient"
+	default:
+		return "unknown"
+	}
+}
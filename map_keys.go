@@ -0,0 +1,45 @@
+package container
+
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+
+// sortedMapKeys returns the keys of the map (in the reflect.Value) in sorted order.
+//
+// Go map iteration order is random. Going through the keys in sorted order instead means
+// that, when more than one element of a map has a problem, the problems are always
+// reported in the same order.
+//
+// Keys of the same (built-in) ordered kind are compared by value; anything else is compared
+// by how it looks in the field path, as in `["apple"]`.
+func sortedMapKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return lessMapKey(keys[i], keys[j])
+	})
+
+	return keys
+}
+
+
+func lessMapKey(a reflect.Value, b reflect.Value) bool {
+	if a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.String:
+			return a.String() < b.String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return a.Int() < b.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return a.Uint() < b.Uint()
+		case reflect.Float32, reflect.Float64:
+			return a.Float() < b.Float()
+		}
+	}
+
+	return fmt.Sprintf("%#v", a.Interface()) < fmt.Sprintf("%#v", b.Interface())
+}
@@ -0,0 +1,218 @@
+package container
+
+
+import (
+	"fmt"
+	"strings"
+)
+
+
+// Module is a reusable bundle of registrations.
+//
+// A library can ship a Module, rather than a list of names that each program using it
+// has to register. For example:
+//
+//	var Module = container.NewModule("db",
+//		[]string{"db"},     // provides
+//		[]string{"config"}, // requires
+//		func(c container.Container) error {
+//			return c.RegisterProvider("db", func(ctx context.Context) (interface{}, error) {
+//				//@TODO
+//			})
+//		},
+//	)
+//
+// Modules are installed with the Install method.
+//
+// Name returns the name of the module (which is used in error messages).
+//
+// Provides returns the names that the module registers.
+//
+// Requires returns the names that the module needs something else to have registered.
+//
+// Register does the registering, with the container the module is being installed into.
+type Module interface {
+	Name() string
+	Provides() []string
+	Requires() []string
+	Register(Container) error
+}
+
+
+// internalModule is the Module that NewModule returns.
+type internalModule struct {
+	name string
+	provides []string
+	requires []string
+	register func(Container) error
+}
+
+
+// NewModule returns a Module with the given name, that provides and requires the given names,
+// and that does its registering by calling the register func.
+func NewModule(name string, provides []string, requires []string, register func(Container) error) Module {
+	module := internalModule{
+		name:name,
+		provides:append([]string(nil), provides...),
+		requires:append([]string(nil), requires...),
+		register:register,
+	}
+
+	return &module
+}
+
+
+func (module *internalModule) Name() string {
+	return module.name
+}
+
+
+func (module *internalModule) Provides() []string {
+	return append([]string(nil), module.provides...)
+}
+
+
+func (module *internalModule) Requires() []string {
+	return append([]string(nil), module.requires...)
+}
+
+
+func (module *internalModule) Register(c Container) error {
+	if nil == module.register {
+		return nil
+	}
+
+	return module.register(c)
+}
+
+
+// Install installs the modules (see Module) into the container.
+//
+// Before anything gets registered, Install makes sure that everything each of the modules
+// requires is either already registered with the container, or is provided by one of the
+// modules being installed. If not, then nothing gets installed, and a DependenciesNotFoundComplainer
+// is returned, as in:
+//
+//	Dependencies not found: "config" required by module db
+//
+// The modules are installed so that a module gets installed after the modules that provide
+// what it requires. (Other than that, they are installed in the order given.) So a module's
+// Register method can get what it requires from the container.
+//
+// If a module's Register method returns an error, then Install stops and returns that error (wrapped).
+// Modules installed before it stay installed.
+//
+// If, after its Register method returns, something that a module said it provides is not
+// registered, then an error is returned for which errors.Is(err, ErrNotFound) reports true.
+func (container *internalContainer) Install(modules ...Module) error {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] Install(%s)", moduleNames(modules))
+
+	if err := container.install(modules); nil != err {
+		logger.Printf("[END]   Install(%s) with ERROR: %q", moduleNames(modules), err)
+		return err
+	}
+
+	logger.Printf("[END]   Install(%s)", moduleNames(modules))
+
+	return nil
+}
+
+func (container *internalContainer) install(modules []Module) error {
+
+	providedBy := map[string]int{}
+	for i, module := range modules {
+		for _, name := range module.Provides() {
+			providedBy[name] = i
+		}
+	}
+
+	dependenciesNotFoundComplainer := newDependenciesNotFoundComplainer().(*internalDependenciesNotFoundComplainer)
+	for _, module := range modules {
+		for _, name := range module.Requires() {
+			if _, ok := providedBy[name]; ok {
+				continue
+			}
+			if container.Has(name) {
+				continue
+			}
+
+			dependenciesNotFoundComplainer.insert(container.qualify(name), "module "+module.Name())
+		}
+	}
+	if 0 < dependenciesNotFoundComplainer.len() {
+		return dependenciesNotFoundComplainer
+	}
+
+	for _, module := range installOrder(modules, providedBy) {
+		if err := module.Register(container); nil != err {
+			return fmt.Errorf("Problem installing module %q: %w", module.Name(), err)
+		}
+
+		for _, name := range module.Provides() {
+			if !container.Has(name) {
+				return fmt.Errorf("Module %q says it provides %q, but did not register it: %w", module.Name(), name, newDependenciesNotFoundComplainer(container.qualify(name)))
+			}
+		}
+	}
+
+	return nil
+}
+
+
+// installOrder returns the modules in the order they should be installed in.
+//
+// A module comes after the (other) modules that provide what it requires. Other than that,
+// the modules stay in the order given. (If the modules require each other in a cycle, then
+// the first of them not yet installed goes next.)
+//
+// The values of providedBy are indexes into modules.
+func installOrder(modules []Module, providedBy map[string]int) []Module {
+	ordered := make([]Module, 0, len(modules))
+	installed := make([]bool, len(modules))
+
+	for len(ordered) < len(modules) {
+		next := -1
+
+		for i, module := range modules {
+			if installed[i] {
+				continue
+			}
+			if -1 == next {
+				next = i
+			}
+
+			ready := true
+			for _, name := range module.Requires() {
+				provider, ok := providedBy[name]
+				if ok && i != provider && !installed[provider] {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				next = i
+				break
+			}
+		}
+
+		ordered = append(ordered, modules[next])
+		installed[next] = true
+	}
+
+	return ordered
+}
+
+
+// moduleNames returns the names of the modules, for logging.
+func moduleNames(modules []Module) string {
+	names := make([]string, len(modules))
+	for i, module := range modules {
+		names[i] = fmt.Sprintf("%q", module.Name())
+	}
+
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,146 @@
+package container
+
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+
+// Namespace returns a view of the container where every name is prefixed with
+// the given prefix.
+//
+// For example:
+//
+//	db := Container.Namespace("db.")
+//	
+//	// This registers "db.primary".
+//	err := db.Register("primary", primaryDB)
+//
+// Everything done through the namespace (registering, getting, injecting, aliasing,
+// listing, and so on) uses the prefixed names. So `inject:"primary"` injected through
+// the namespace gets "db.primary". This keeps what each module registers separate
+// from what the other modules register.
+//
+// A namespace can itself have a namespace. In which case the prefixes are combined.
+func (container *internalContainer) Namespace(prefix string) Container {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] Namespace(%q)", prefix)
+
+	namespace := *container
+	namespace.namespace = container.namespace + prefix
+
+	logger.Printf("[END]   Namespace(%q)", prefix)
+
+	return &namespace
+}
+
+
+// List returns (in sorted order) every registered name (including aliases) that begins
+// with the prefix.
+//
+// For example:
+//
+//	names := Container.List("db.")
+//
+// might return:
+//
+//	[]string{"db.primary", "db.replica"}
+//
+// (If called on a namespace, then the names are relative to the namespace.)
+func (container *internalContainer) List(prefix string) []string {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] List(%q)", prefix)
+
+	var names []string
+	for _, name := range container.names() {
+		if strings.HasPrefix(name, container.qualify(prefix)) {
+			names = append(names, container.unqualify(name))
+		}
+	}
+
+	logger.Printf("[END]   List(%q)", prefix)
+
+	return names
+}
+
+
+// qualify returns the name prefixed with the container's namespace.
+func (container *internalContainer) qualify(name string) string {
+	return container.namespace + name
+}
+
+
+// unqualify returns the name without the container's namespace prefix.
+//
+// (If the name is not in the container's namespace, then it is returned as is.)
+func (container *internalContainer) unqualify(name string) string {
+	return strings.TrimPrefix(name, container.namespace)
+}
+
+
+// names returns (in sorted order) every registered name (including aliases) in this
+// container and its ancestors.
+func (container *internalContainer) names() []string {
+	set := map[string]struct{}{}
+
+	for c := container; nil != c; c = c.parent {
+		c.mutex.RLock()
+		for name := range c.registry {
+			set[name] = struct{}{}
+		}
+		for name := range c.aliases {
+			set[name] = struct{}{}
+		}
+		c.mutex.RUnlock()
+	}
+
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+
+// resolvePrefix returns everything registered with a name that begins with the prefix,
+// as a map of the fieldType. The keys of the map are the names with the prefix removed.
+//
+// If nothing is registered with a name that begins with the prefix, then it returns an empty map.
+func (container *internalContainer) resolvePrefix(ctx context.Context, tag injectTag, requiredBy string, fieldType reflect.Type, fieldPath string) (dependency interface{}, ok bool, err error) {
+	var names []string
+	for _, name := range container.names() {
+		if strings.HasPrefix(name, tag.name) {
+			names = append(names, name)
+		}
+	}
+	if reflect.Map != fieldType.Kind() || reflect.String != fieldType.Key().Kind() {
+		return nil, true, newWrongTypeComplainer(tag.raw, fieldPath)
+	}
+
+	m := reflect.MakeMapWithSize(fieldType, len(names))
+	for _, name := range names {
+		dependency, _, err := container.resolve(ctx, name, requiredBy)
+		if nil != err {
+			return nil, true, err
+		}
+
+		value := reflect.ValueOf(dependency)
+		if !value.IsValid() || !value.Type().AssignableTo(fieldType.Elem()) {
+			return nil, true, newWrongTypeComplainer(tag.raw, fieldPath)
+		}
+
+		m.SetMapIndex(reflect.ValueOf(name[len(tag.name):]).Convert(fieldType.Key()), value)
+	}
+
+	return m.Interface(), true, nil
+}
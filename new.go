@@ -0,0 +1,68 @@
+package container
+
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+
+// New makes a new struct, of the type pointed to, injects its dependencies into it (see Inject),
+// and then returns (a pointer to) it. For example:
+//
+//	thing, err := Container.New((*billing.Service)(nil))
+//	if nil != err {
+//		//@TODO
+//	}
+//	
+//	service := thing.(*billing.Service)
+//
+// What is passed to New is only used for its type. So it can be a nil pointer (as above), a
+// pointer to a struct (as in &billing.Service{}), or the reflect.Type of a pointer to a struct.
+//
+// If what is passed is not one of those, then an error is returned. If injecting returns an
+// error (including an AfterInjectComplainer), then that error is returned.
+//
+// Since New injects the same way Inject does, any setting up that the struct needs to do with its
+// dependencies can be done in an AfterInject method (see AfterInjecter).
+func (container *internalContainer) New(typ interface{}) (interface{}, error) {
+	return container.NewContext(context.Background(), typ)
+}
+
+
+func (container *internalContainer) NewContext(ctx context.Context, typ interface{}) (interface{}, error) {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] NewContext(%v)", typ)
+
+	thing, err := container.newValue(ctx, typ)
+	if nil != err {
+		logger.Printf("[END]   NewContext(%v) with ERROR: %q", typ, err)
+		return nil, err
+	}
+
+	logger.Printf("[END]   NewContext(%v)", typ)
+
+	return thing, nil
+}
+
+func (container *internalContainer) newValue(ctx context.Context, typ interface{}) (interface{}, error) {
+	t, ok := typ.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(typ)
+	}
+
+	if nil == t || reflect.Ptr != t.Kind() || reflect.Struct != t.Elem().Kind() {
+		return nil, fmt.Errorf("Cannot make a new %v. It needs to be a pointer to a struct.", t)
+	}
+
+	thing := reflect.New(t.Elem()).Interface()
+
+	if err := container.InjectContext(ctx, thing); nil != err {
+		return nil, err
+	}
+
+	return thing, nil
+}
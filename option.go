@@ -0,0 +1,49 @@
+package container
+
+
+// Option is something that can be passed to the New func, to change how the
+// 'dependency injection container' behaves.
+type Option func(*internalContainer)
+
+
+// CollectAllErrors returns an Option that makes the container's Inject method
+// continue on past problems.
+//
+// Normally, the Inject method keeps going when a dependency is not registered
+// (so that it can report all the missing dependencies at once), but it stops
+// at the first other problem (such as a dependency being of the wrong type).
+//
+// With this option, the Inject method goes through every struct field and every
+// element of every slice, array and map, and reports every problem it finds.
+// If there is more than one problem, then what is returned is an InjectionComplainer.
+// The problems are in the order they were found; map elements are gone through
+// in sorted key order, so the same input always gives the same error.
+//
+// This is useful for catching all the wiring mistakes in a single run, as in:
+//
+//	Container := container.New(container.CollectAllErrors())
+func CollectAllErrors() Option {
+	return func(container *internalContainer) {
+		container.collectAllErrors = true
+	}
+}
+
+
+// Strict returns an Option that makes the container's Inject method check,
+// before injecting anything, that every struct field with an `inject` struct
+// tag can actually be set.
+//
+// Normally, an `inject` struct tag on an unexported struct field results in
+// a hard to understand ProblemInjectingDependencyComplainer (and a struct tag
+// on a struct that is not addressable is silently ignored).
+//
+// With this option, the Inject method instead returns an UnsettableFieldComplainer
+// (or an InjectionComplainer, if there is more than one) without having injected
+// anything.
+//
+// See also the Check func.
+func Strict() Option {
+	return func(container *internalContainer) {
+		container.strict = true
+	}
+}
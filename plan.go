@@ -0,0 +1,103 @@
+package container
+
+
+import (
+	"reflect"
+	"sync"
+)
+
+
+// injectionPlan is what is needed to inject into a struct type, worked out once (with
+// reflection) so that it does not need to be worked out again each time something of
+// that type is injected into.
+//
+// Only the struct fields that have an `inject` struct tag are in it.
+type injectionPlan struct {
+	fields []plannedField
+}
+
+
+// plannedField is a struct field (that has an `inject` struct tag) of an injectionPlan.
+type plannedField struct {
+	index int
+	name string
+
+	// path is what gets appended to the path of the struct, as in:
+	//
+	//	.DB
+	path string
+
+	typ reflect.Type
+	exported bool
+
+	// deferred is whether the struct field is a Lazy[T] or a Provider[T].
+	deferred bool
+
+	// dependencyName is the (raw) value of the `inject` struct tag, without any options.
+	dependencyName string
+	tag injectTag
+
+	// requiredBy is the pointer type and field name, as in:
+	//
+	//	*billing.Service.DB
+	requiredBy string
+}
+
+
+// injectionPlans caches the injectionPlan for each struct type. (The keys are reflect.Types
+// and the values are *injectionPlans.)
+//
+// Since types never change, the cache is shared by all containers, and never needs to be
+// cleared.
+var injectionPlans sync.Map
+
+
+// planFor returns the injectionPlan for the struct type.
+func planFor(typ reflect.Type) *injectionPlan {
+	if plan, ok := injectionPlans.Load(typ); ok {
+		return plan.(*injectionPlan)
+	}
+
+	plan, _ := injectionPlans.LoadOrStore(typ, newInjectionPlan(typ))
+
+	return plan.(*injectionPlan)
+}
+
+
+// newInjectionPlan works out the injectionPlan for the struct type.
+func newInjectionPlan(typ reflect.Type) *injectionPlan {
+	var plan injectionPlan
+
+	typeName := reflect.PtrTo(typ).String()
+
+	numFields := typ.NumField()
+	for i:=0; i<numFields; i++ {
+		field := typ.Field(i)
+
+		// Note that reflection will always return a value for the 'struct tag'
+		// we ask for, regardless of whether the programmer added our specific
+		// 'struct tag' or not. (It returns "" (i.e., the empty string) when it
+		// is not there.) So a field with an `inject:""` struct tag is treated
+		// the same as a field without an `inject` struct tag.
+		value := field.Tag.Get("inject")
+		if "" == value {
+			continue
+		}
+
+		tag := parseInjectTag(value)
+
+		plan.fields = append(plan.fields, plannedField{
+			index:i,
+			name:field.Name,
+			path:"." + field.Name,
+			typ:field.Type,
+			exported:"" == field.PkgPath,
+			deferred:reflect.PtrTo(field.Type).Implements(deferredInjectableType),
+			dependencyName:tag.raw,
+			tag:tag,
+			requiredBy:typeName + "." + field.Name,
+		})
+	}
+
+	return &plan
+}
@@ -7,23 +7,39 @@ import (
 )
 
 
+// ProblemInjectingDependencyComplainer is an 'error' that represents the situation
+// where something went wrong while the 'dependency injection container' was setting
+// a struct field.
+//
+// The Path method says where the struct field is, relative to what was passed
+// to the Inject method. For example:
+//
+//	["eu-west"][3].Dependencies().Store
+//
+// The error that caused the problem is returned by the Err method. It is also
+// returned by the Unwrap method, so errors.Is and errors.As see it.
+//
+// errors.Is(err, ErrProblemInjectingDependency) reports true for a ProblemInjectingDependencyComplainer.
 type ProblemInjectingDependencyComplainer interface {
 	error
 	ProblemInjectingDependencyComplainer()
+	Path() string
 	Err() error
 }
 
 
 type internalProblemInjectingDependencyComplainer struct {
 	dependencyName string
+	path string
 	reflectedValue reflect.Value
 	err error
 }
 
 
-func newProblemInjectingDependencyComplainer(dependencyName string, reflectedValue reflect.Value, err error) error {
+func newProblemInjectingDependencyComplainer(dependencyName string, path string, reflectedValue reflect.Value, err error) error {
 	complainer := internalProblemInjectingDependencyComplainer{
 		dependencyName:dependencyName,
+		path:path,
 		reflectedValue:reflectedValue,
 		err:err,
 	}
@@ -33,7 +49,12 @@ func newProblemInjectingDependencyComplainer(dependencyName string, reflectedVal
 
 
 func (complainer *internalProblemInjectingDependencyComplainer) Error() string {
-	return fmt.Sprintf("Problem injecting dependency %q with value %v (%s) (%s): %v", complainer.dependencyName, complainer.reflectedValue, complainer.reflectedValue.Kind().String(), complainer.reflectedValue.String(), complainer.err)
+	var at string
+	if "" != complainer.path {
+		at = " at " + complainer.path
+	}
+
+	return fmt.Sprintf("Problem injecting dependency %q%s with value %v (%s) (%s): %v", complainer.dependencyName, at, complainer.reflectedValue, complainer.reflectedValue.Kind().String(), complainer.reflectedValue.String(), complainer.err)
 }
 
 
@@ -42,6 +63,25 @@ func (complainer *internalProblemInjectingDependencyComplainer) ProblemInjecting
 }
 
 
+func (complainer *internalProblemInjectingDependencyComplainer) Path() string {
+	return complainer.path
+}
+
+
 func (complainer *internalProblemInjectingDependencyComplainer) Err() error {
 	return complainer.err
 }
+
+
+// Unwrap returns the same thing as the Err method.
+//
+// This makes it so errors.Is and errors.As can see the underlying error.
+func (complainer *internalProblemInjectingDependencyComplainer) Unwrap() error {
+	return complainer.err
+}
+
+
+// Is makes it so errors.Is(err, ErrProblemInjectingDependency) works.
+func (complainer *internalProblemInjectingDependencyComplainer) Is(target error) bool {
+	return ErrProblemInjectingDependency == target
+}
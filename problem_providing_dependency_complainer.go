@@ -0,0 +1,76 @@
+package container
+
+
+import (
+	"fmt"
+)
+
+
+// ProblemProvidingDependencyComplainer is an 'error' that represents the situation
+// where the provider of a lazily constructed dependency (see RegisterProvider)
+// returned an error.
+//
+// The error the provider returned is returned by the Err method. It is also
+// returned by the Unwrap method, so errors.Is and errors.As see it.
+//
+// errors.Is(err, ErrProblemProvidingDependency) reports true for a ProblemProvidingDependencyComplainer.
+type ProblemProvidingDependencyComplainer interface {
+	error
+	ProblemProvidingDependencyComplainer()
+	DependencyName() string
+	Err() error
+}
+
+
+// internalProblemProvidingDependencyComplainer is the only underlying implementation that fits the
+// ProblemProvidingDependencyComplainer interface, in this library.
+type internalProblemProvidingDependencyComplainer struct {
+	dependencyName string
+	err error
+}
+
+
+// newProblemProvidingDependencyComplainer creates a new internalProblemProvidingDependencyComplainer (struct) and
+// returns it as a ProblemProvidingDependencyComplainer (interface).
+func newProblemProvidingDependencyComplainer(dependencyName string, err error) ProblemProvidingDependencyComplainer {
+	complainer := internalProblemProvidingDependencyComplainer{
+		dependencyName:dependencyName,
+		err:err,
+	}
+
+	return &complainer
+}
+
+
+func (complainer *internalProblemProvidingDependencyComplainer) Error() string {
+	return fmt.Sprintf("Problem providing dependency %q: %v", complainer.dependencyName, complainer.err)
+}
+
+
+func (complainer *internalProblemProvidingDependencyComplainer) ProblemProvidingDependencyComplainer() {
+	// Nothing here.
+}
+
+
+// DependencyName method is necessary to satisfy the 'ProblemProvidingDependencyComplainer' interface.
+func (complainer *internalProblemProvidingDependencyComplainer) DependencyName() string {
+	return complainer.dependencyName
+}
+
+
+// Err method is necessary to satisfy the 'ProblemProvidingDependencyComplainer' interface.
+func (complainer *internalProblemProvidingDependencyComplainer) Err() error {
+	return complainer.err
+}
+
+
+// Unwrap returns the same thing as the Err method.
+func (complainer *internalProblemProvidingDependencyComplainer) Unwrap() error {
+	return complainer.err
+}
+
+
+// Is makes it so errors.Is(err, ErrProblemProvidingDependency) works.
+func (complainer *internalProblemProvidingDependencyComplainer) Is(target error) bool {
+	return ErrProblemProvidingDependency == target
+}
@@ -0,0 +1,67 @@
+package container
+
+
+import (
+	"reflect"
+)
+
+
+// Provider is a struct field type for getting a dependency on demand, each time it is needed.
+//
+// When a struct field of type Provider[T] is injected into, what is injected is a func that
+// resolves the dependency each time it is called. For example:
+//
+//	type Worker struct {
+//		UnitOfWork container.Provider[*UnitOfWork] `inject:"unit-of-work"`
+//	}
+//
+//	func (worker *Worker) Do(job Job) error {
+//		unitOfWork, err := worker.UnitOfWork()
+//		if nil != err {
+//			return err
+//		}
+//		defer unitOfWork.Close()
+//
+//		//@TODO
+//	}
+//
+// What the func returns depends on how the dependency was registered. A dependency registered
+// with RegisterTransient is constructed anew each time. A dependency registered with RegisterScoped
+// is the one for the scope that did the injecting. And a dependency registered with Register or
+// RegisterProvider is the same one each time.
+//
+// Whether the dependency is registered is still checked when injecting into the struct field (so a
+// DependenciesNotFoundComplainer is still returned by Inject, if it is not).
+//
+// The dependency is resolved from the container that did the injecting, with the context.Context that
+// was passed to InjectContext (except that it being canceled, or its deadline being exceeded, does not
+// matter).
+// If that container (for example, a scope) has been closed by then (see the Close method), then a
+// ClosedComplainer is returned.
+//
+// A Provider[T] that was never injected into is nil.
+type Provider[T any] func() (T, error)
+
+
+// deferredType returns the type of the dependency, T.
+func (provider *Provider[T]) deferredType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+
+func (provider *Provider[T]) injectDeferred(resolve func() (interface{}, error)) {
+	*provider = func() (T, error) {
+		var value T
+
+		dependency, err := resolve()
+		if nil != err {
+			return value, err
+		}
+
+		if nil != dependency {
+			value = dependency.(T)
+		}
+
+		return value, nil
+	}
+}
@@ -0,0 +1,381 @@
+package container
+
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+
+// registration is what is stored in the container's registry, for each registered
+// dependency.
+//
+// A registration either holds the dependency itself (from Register), or holds a
+// provider that constructs the dependency lazily (from RegisterProvider).
+//
+// A scoped registration (from RegisterScoped) never gets its provider called
+// directly. Instead, each scope makes its own (unscoped) copy of it. (See the
+// scopedRegistration method.)
+//
+// A transient registration (from RegisterTransient) has its provider called each
+// time the dependency is needed, and never holds on to what it provided. (See the
+// getNew method.)
+//
+// Once the container the registration is in is sealed (see Seal), the registration
+// cannot be decorated anymore.
+type registration struct {
+	mutex sync.Mutex
+	dependency interface{}
+	provider func(context.Context) (interface{}, error)
+	provided bool
+
+	// value points to the dependency once it has been provided, and is nil before that.
+	// It is only ever changed while the mutex is held (along with dependency and provided,
+	// see the setDependency method), but can be read without it. (So that getting an
+	// already provided dependency does not need the mutex.)
+	value atomic.Pointer[interface{}]
+
+	// onProvided, if not nil, is called (without the mutex held) each time the provider's
+	// dependency gets provided. (It is how a container keeps track of what it constructed,
+	// so that it can close it. See the Close method.)
+	onProvided func(*registration)
+
+	// inflight is not nil while the provider is being called. It gets closed once
+	// the provider returns. (See the get method.)
+	inflight chan struct{}
+
+	scoped bool
+	transient bool
+	decorators []func(interface{}) (interface{}, error)
+	site string
+
+	// dependents is what required the dependency. (The keys are strings, and the values
+	// are struct{}{}.) It is a sync.Map (rather than a map guarded by the mutex) since
+	// the same things get noted each time a struct is injected into, and after the first
+	// time, noting them again is just a lock-free read. (See the addDependent method.)
+	dependents sync.Map
+
+	sealed bool
+}
+
+
+// newRegistration returns a registration for a dependency that was registered as is.
+func newRegistration(dependency interface{}) *registration {
+	var reg registration
+	reg.setDependency(dependency, true)
+
+	return &reg
+}
+
+
+// newProviderRegistration returns a registration for a dependency that is constructed
+// lazily, by calling the provider.
+func newProviderRegistration(provider func(context.Context) (interface{}, error)) *registration {
+	reg := registration{
+		provider:provider,
+	}
+
+	return &reg
+}
+
+
+// newScopedRegistration returns a registration for a dependency that is constructed
+// lazily, by calling the provider, once per scope.
+func newScopedRegistration(provider func(context.Context) (interface{}, error)) *registration {
+	reg := registration{
+		provider:provider,
+		scoped:true,
+	}
+
+	return &reg
+}
+
+
+// newTransientRegistration returns a registration for a dependency that is constructed
+// anew, by calling the provider, each time it is needed.
+func newTransientRegistration(provider func(context.Context) (interface{}, error)) *registration {
+	reg := registration{
+		provider:provider,
+		transient:true,
+	}
+
+	return &reg
+}
+
+
+// lifetime returns the Lifetime of the registration.
+func (reg *registration) lifetime() Lifetime {
+	switch {
+	case reg.scoped:
+		return LifetimeScoped
+	case reg.transient:
+		return LifetimeTransient
+	case nil != reg.provider:
+		return LifetimeLazySingleton
+	default:
+		return LifetimeSingleton
+	}
+}
+
+
+// declaredType returns the type of the dependency, if it is known from how it was registered.
+// That is, if the dependency was registered as is (rather than with a provider).
+//
+// (The type of a dependency registered with a provider is never known this way, even after it
+// has been provided. So that what the type is known to be does not depend on what has been
+// provided so far.)
+func (reg *registration) declaredType() (reflect.Type, bool) {
+	if nil != reg.provider {
+		return nil, false
+	}
+
+	reg.mutex.Lock()
+	defer reg.mutex.Unlock()
+
+	if nil == reg.dependency {
+		return nil, false
+	}
+
+	return reflect.TypeOf(reg.dependency), true
+}
+
+
+// addDependent makes a note of something that required the dependency.
+//
+// Each thing only gets noted once. (So, for example, each struct field with an `inject`
+// struct tag only gets noted the first time its type is injected into.)
+func (reg *registration) addDependent(requiredBy string) {
+	if _, ok := reg.dependents.Load(requiredBy); ok {
+		return
+	}
+
+	reg.dependents.Store(requiredBy, struct{}{})
+}
+
+
+// dependentNames returns (in no particular order) what required the dependency. (See the
+// addDependent method.)
+func (reg *registration) dependentNames() []string {
+	var names []string
+
+	reg.dependents.Range(func(key, value interface{}) bool {
+		names = append(names, key.(string))
+		return true
+	})
+
+	return names
+}
+
+
+// setDependency sets the dependency, and whether it has been provided.
+//
+// The registration's mutex must be held while calling it. (Unless nothing else can see the
+// registration yet.)
+func (reg *registration) setDependency(dependency interface{}, provided bool) {
+	reg.dependency = dependency
+	reg.provided = provided
+
+	if !provided {
+		reg.value.Store(nil)
+		return
+	}
+
+	reg.value.Store(&dependency)
+}
+
+
+// get returns the dependency, calling the provider if that has not already been done.
+//
+// Neither the container's mutex nor the registration's own mutex is held while the provider
+// is called. (So the provider is free to use the container.) Instead, the registration is
+// marked as in-flight, and anything else that needs the dependency in the meantime waits for
+// the provider to return (or for its own context.Context to be done, whichever comes first).
+//
+// If the dependency is already being resolved further up (in the context.Context) then a
+// CycleComplainer is returned, rather than waiting for what would never happen.
+func (reg *registration) get(ctx context.Context, dependencyName string) (interface{}, error) {
+	if value := reg.value.Load(); nil != value {
+		return *value, nil
+	}
+
+	for {
+		reg.mutex.Lock()
+
+		if reg.provided {
+			dependency := reg.dependency
+			reg.mutex.Unlock()
+			return dependency, nil
+		}
+
+		if cycle, ok := resolvingFrom(ctx).cycle(reg, dependencyName); ok {
+			reg.mutex.Unlock()
+			return nil, newCycleComplainer(cycle...)
+		}
+
+		inflight := reg.inflight
+		if nil == inflight {
+			break
+		}
+
+		reg.mutex.Unlock()
+
+		select {
+		case <-inflight:
+			// Whatever was calling the provider is done. So see what happened.
+		case <-ctx.Done():
+			return nil, newCanceledComplainer(dependencyName, ctx.Err())
+		}
+	}
+
+	// The registration's mutex is still held here.
+	inflight := make(chan struct{})
+	reg.inflight = inflight
+	provider   := reg.provider
+	decorators := reg.decorators
+	reg.mutex.Unlock()
+
+	var dependency interface{}
+	var err error
+	var returned bool
+
+	func() {
+		// Whatever happens (even a panic), the registration is no longer in-flight once
+		// the provider returns.
+		defer func() {
+			var provided bool
+
+			reg.mutex.Lock()
+			if returned && nil == err && !reg.provided {
+				// Decorators added while the provider was being called still apply.
+				dependency, err = decorate(dependency, reg.decorators[len(decorators):])
+				if nil == err {
+					reg.setDependency(dependency, true)
+					provided = true
+				}
+			}
+			reg.inflight = nil
+			reg.mutex.Unlock()
+
+			close(inflight)
+
+			if provided && nil != reg.onProvided {
+				reg.onProvided(reg)
+			}
+		}()
+
+		dependency, err = provider(withResolving(ctx, reg, dependencyName))
+		if nil == err {
+			dependency, err = decorate(dependency, decorators)
+		}
+		returned = true
+	}()
+
+	if nil != err {
+		return nil, providerComplainer(ctx, dependencyName, err)
+	}
+
+	return dependency, nil
+}
+
+
+// getNew returns a new dependency, by calling the provider (and then the decorators), without
+// remembering the result.
+//
+// This is how transient dependencies are gotten. Like get, no mutexes are held while the provider
+// is called. (So calls can happen at the same time.)
+func (reg *registration) getNew(ctx context.Context, dependencyName string) (interface{}, error) {
+	if cycle, ok := resolvingFrom(ctx).cycle(reg, dependencyName); ok {
+		return nil, newCycleComplainer(cycle...)
+	}
+
+	dependency, err := reg.construct(withResolving(ctx, reg, dependencyName))
+	if nil != err {
+		return nil, providerComplainer(ctx, dependencyName, err)
+	}
+
+	return dependency, nil
+}
+
+
+// providerComplainer returns the complainer for an error returned by a provider (or a decorator).
+func providerComplainer(ctx context.Context, dependencyName string, err error) error {
+	// If the provider gave up because the context.Context is done, then
+	// say that.
+	if ctxErr := ctx.Err(); nil != ctxErr {
+		return newCanceledComplainer(dependencyName, ctxErr)
+	}
+
+	// A scope's own decorated copy of a registration (see the Decorate method) gets the
+	// dependency from the registration it is a copy of. Which already says what the
+	// problem is.
+	if complainer, ok := err.(ProblemProvidingDependencyComplainer); ok && dependencyName == complainer.DependencyName() {
+		return err
+	}
+
+	return newProblemProvidingDependencyComplainer(dependencyName, err)
+}
+
+
+// construct calls the provider, and then the decorators, without remembering the result.
+//
+// This is what each scope's copy of a scoped registration uses as its provider.
+func (reg *registration) construct(ctx context.Context) (interface{}, error) {
+	reg.mutex.Lock()
+	provider   := reg.provider
+	decorators := reg.decorators
+	reg.mutex.Unlock()
+
+	dependency, err := provider(ctx)
+	if nil != err {
+		return nil, err
+	}
+
+	return decorate(dependency, decorators)
+}
+
+
+// addDecorator adds a decorator to the registration.
+//
+// If the dependency has already been provided, then the decorator is applied to it right
+// away. Else the decorator is applied (after any other decorators) once the dependency is
+// provided.
+func (reg *registration) addDecorator(dependencyName string, decorator func(interface{}) (interface{}, error)) error {
+	reg.mutex.Lock()
+	defer reg.mutex.Unlock()
+
+	if reg.sealed {
+		return newSealedComplainer(dependencyName)
+	}
+
+	if reg.scoped || reg.transient || !reg.provided {
+		reg.decorators = append(reg.decorators, decorator)
+		return nil
+	}
+
+	dependency, err := decorator(reg.dependency)
+	if nil != err {
+		return newProblemProvidingDependencyComplainer(dependencyName, err)
+	}
+
+	reg.setDependency(dependency, true)
+
+	return nil
+}
+
+
+// decorate calls each of the decorators (in order), each one with what the previous one
+// returned.
+func decorate(dependency interface{}, decorators []func(interface{}) (interface{}, error)) (interface{}, error) {
+	for _, decorator := range decorators {
+		var err error
+
+		dependency, err = decorator(dependency)
+		if nil != err {
+			return nil, err
+		}
+	}
+
+	return dependency, nil
+}
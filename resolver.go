@@ -0,0 +1,85 @@
+package container
+
+
+import (
+	"context"
+)
+
+
+// containerTag is the `inject` struct tag that injects a Resolver for the container doing
+// the injecting, rather than a registered dependency. As in:
+//
+//	Resolver container.Resolver `inject:"@container"`
+const containerTag = "@container"
+
+
+// Resolver is a restricted handle to a container, that can only be used to resolve
+// dependencies.
+//
+// It is what gets injected into a struct field with the `inject:"@container"` struct tag.
+// (And what an Invoke or Construct func gets, for a parameter of type Resolver that isn't
+// given a name.) For example:
+//
+//	type PluginLoader struct {
+//		Resolver container.Resolver `inject:"@container"`
+//	}
+//
+//	func (loader *PluginLoader) Load(plugin Plugin) error {
+//		for _, name := range plugin.Requires() {
+//			dependency, err := loader.Resolver.Get(name)
+//			if nil != err {
+//				return err
+//			}
+//
+//			//@TODO
+//		}
+//
+//		//@TODO
+//	}
+//
+// This is meant to be used instead of registering the Container under a name. A Resolver
+// cannot be used to register anything, and it cannot be type-asserted back into a Container.
+//
+// A Resolver resolves dependencies from the container that did the injecting. So, if that was
+// a Scope, then scoped dependencies are resolved from that Scope. And if that was a namespace
+// (see the Namespace method), then names are resolved within that namespace.
+type Resolver interface {
+	Has(string) bool
+	Names() []string
+
+	Get(string) (interface{}, error)
+	GetContext(context.Context, string) (interface{}, error)
+}
+
+
+type internalResolver struct {
+	container *internalContainer
+}
+
+
+// resolver returns a Resolver for the container.
+func (container *internalContainer) resolver() Resolver {
+	return &internalResolver{
+		container:container,
+	}
+}
+
+
+func (resolver *internalResolver) Has(dependencyName string) bool {
+	return resolver.container.Has(dependencyName)
+}
+
+
+func (resolver *internalResolver) Names() []string {
+	return resolver.container.Names()
+}
+
+
+func (resolver *internalResolver) Get(dependencyName string) (interface{}, error) {
+	return resolver.container.Get(dependencyName)
+}
+
+
+func (resolver *internalResolver) GetContext(ctx context.Context, dependencyName string) (interface{}, error) {
+	return resolver.container.GetContext(ctx, dependencyName)
+}
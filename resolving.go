@@ -0,0 +1,83 @@
+package container
+
+
+import (
+	"context"
+)
+
+
+// resolvingKey is the key of the context.Context value that holds the resolvingStack.
+type resolvingKey struct{}
+
+
+// resolvingStack is the dependencies whose providers are being called, further up. (It is
+// carried in the context.Context passed to providers, so that, if a provider needs a dependency
+// that is already being resolved further up, that can be noticed, rather than waiting forever.)
+//
+// The top of the stack is the dependency that was most recently started being resolved. A nil
+// *resolvingStack is an empty stack.
+type resolvingStack struct {
+	reg *registration
+	dependencyName string
+	next *resolvingStack
+}
+
+
+// resolvingFrom returns the resolvingStack in the context.Context (which might be nil, meaning
+// an empty stack).
+func resolvingFrom(ctx context.Context) *resolvingStack {
+	stack, _ := ctx.Value(resolvingKey{}).(*resolvingStack)
+	return stack
+}
+
+
+// withResolving returns a context.Context with the registration pushed onto its resolvingStack.
+func withResolving(ctx context.Context, reg *registration, dependencyName string) context.Context {
+	stack := resolvingStack{
+		reg:reg,
+		dependencyName:dependencyName,
+		next:resolvingFrom(ctx),
+	}
+
+	return context.WithValue(ctx, resolvingKey{}, &stack)
+}
+
+
+// withoutResolving returns a context.Context with an empty resolvingStack.
+//
+// This is for when a dependency is going to be resolved later on (see Lazy and Provider),
+// rather than as part of what is being resolved now.
+func withoutResolving(ctx context.Context) context.Context {
+	if nil == resolvingFrom(ctx) {
+		return ctx
+	}
+
+	return context.WithValue(ctx, resolvingKey{}, (*resolvingStack)(nil))
+}
+
+
+// cycle returns the names of the dependencies being resolved, from where the registration is
+// in the stack to the top of the stack, followed by the dependency name. As in:
+//
+//	[]string{"a", "b", "a"}
+//
+// If the registration is not in the stack, then ok is false.
+func (stack *resolvingStack) cycle(reg *registration, dependencyName string) (cycle []string, ok bool) {
+	var names []string
+
+	for s := stack; nil != s; s = s.next {
+		names = append(names, s.dependencyName)
+
+		if reg != s.reg {
+			continue
+		}
+
+		for i := len(names)-1; 0 <= i; i-- {
+			cycle = append(cycle, names[i])
+		}
+
+		return append(cycle, dependencyName), true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,247 @@
+package container
+
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+
+// Scope is a child 'dependency injection container', as returned by the
+// NewScope method.
+//
+// A scope sees everything registered with its parent (and its parent's parent,
+// and so on). Anything registered with the scope itself is only seen by the
+// scope (and its own children). Something registered with the scope can use
+// the same name as something registered with one of its ancestors, in which
+// case the scope sees its own.
+//
+// Dependencies registered with RegisterScoped are constructed once per scope.
+//
+// When the scope is no longer needed, call its Close method. That closes (in
+// the reverse of the order they were constructed) each of the scoped dependencies
+// that the scope constructed, that has a Close method (i.e., that fits io.Closer).
+// (See the Close method.)
+//
+// A typical use of a scope is to have one per HTTP request. For example:
+//
+//	scope := Container.NewScope()
+//	defer scope.Close()
+//	
+//	if err := scope.Register("http.request", r); nil != err {
+//		//@TODO
+//	}
+type Scope interface {
+	Container
+}
+
+
+// RegisterScoped registers a dependency that is constructed lazily, once per scope.
+//
+// Each scope (see NewScope) calls the provider (at most once) the first time the
+// dependency is needed through that scope. (If the dependency is needed through
+// a container that is not a scope, then that container acts as its own scope.)
+//
+// For example:
+//
+//	err := Container.RegisterScoped("unit-of-work", func(ctx context.Context) (interface{}, error) {
+//		return NewUnitOfWork(ctx)
+//	})
+func (container *internalContainer) RegisterScoped(dependencyName string, provider func(context.Context) (interface{}, error)) error {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] RegisterScoped(%q, <provider>)", dependencyName)
+
+	if err := container.register(dependencyName, newScopedRegistration(provider)); nil != err {
+		logger.Printf("[END]   RegisterScoped(%q, <provider>) with ERROR: %q", dependencyName, err)
+		return err
+	}
+
+	logger.Printf("[END]   RegisterScoped(%q, <provider>)", dependencyName)
+
+	return nil
+}
+
+
+// NewScope returns a new child container.
+func (container *internalContainer) NewScope() Scope {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] NewScope()")
+
+	scope := internalContainer{
+		mutex:new(sync.RWMutex),
+		registry:make(map[string]*registration),
+		aliases:make(map[string]string),
+		groups:make(map[string][]groupMember),
+		decorated:make(map[*registration]*registration),
+		parent:container,
+		namespace:container.namespace,
+		scoped:newScopedInstances(),
+		closed:new(atomic.Bool),
+		sealed:new(atomic.Pointer[sealedRegistry]),
+		dependencies:container.dependencies,
+		collectAllErrors:container.collectAllErrors,
+		strict:container.strict,
+	}
+
+	logger.Printf("[END]   NewScope()")
+
+	return &scope
+}
+
+
+// Close closes (in the reverse of the order they were constructed) each of the dependencies
+// that this container constructed, that fits io.Closer. That is, each of the scoped dependencies
+// it constructed (see RegisterScoped), and each of the dependencies registered with it with
+// RegisterProvider that has been provided.
+//
+// Before that, if the dependency fits a BeforeDestroyer, then its BeforeDestroy method is called.
+//
+// Dependencies registered as is (with Register) are not closed, since the container did not
+// construct them. Neither are transient dependencies (see RegisterTransient), since the container
+// does not hold on to them.
+//
+// All of them are closed, even if closing some of them returns an error.
+// The errors are returned together, using errors.Join.
+//
+// Once closed, nothing more can be resolved through the container (or through its scopes).
+// Trying to (for example, with a Lazy[T] or Provider[T] injected from it) returns a
+// ClosedComplainer. And anything that was still being constructed when the container was
+// closed is closed as soon as it has been constructed.
+func (container *internalContainer) Close() error {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] Close()")
+
+	container.mutex.Lock()
+	container.closed.Store(true)
+	constructed := container.scoped.order
+	*container.scoped = *newScopedInstances()
+	container.mutex.Unlock()
+
+	var errs []error
+	for i := len(constructed)-1; 0 <= i; i-- {
+		errs = append(errs, destroy(constructed[i])...)
+	}
+
+	if err := errors.Join(errs...); nil != err {
+		logger.Printf("[END]   Close() with ERROR: %q", err)
+		return err
+	}
+
+	logger.Printf("[END]   Close()")
+
+	return nil
+}
+
+
+// scopedRegistration returns this scope's own copy of the scoped registration.
+//
+// The copy is what actually gets its provider called. So each scope constructs
+// its own instance of the dependency.
+func (container *internalContainer) scopedRegistration(reg *registration) *registration {
+	container.mutex.Lock()
+	defer container.mutex.Unlock()
+
+	if scoped, ok := container.scoped.registrations[reg]; ok {
+		return scoped
+	}
+
+	scoped := newProviderRegistration(reg.construct)
+	scoped.onProvided = container.constructed
+
+	container.scoped.registrations[reg] = scoped
+
+	return scoped
+}
+
+
+// constructed makes a note that the registration's dependency was provided, so that the
+// Close method closes it.
+//
+// If the container has already been closed, then (since the Close method will not be called
+// again) the dependency is closed right away. (Any errors from that are logged.)
+func (container *internalContainer) constructed(reg *registration) {
+	container.mutex.Lock()
+	closed := container.closed.Load()
+	if !closed {
+		container.scoped.order = append(container.scoped.order, reg)
+	}
+	container.mutex.Unlock()
+
+	if !closed {
+		return
+	}
+
+	for _, err := range destroy(reg) {
+		container.dependencies.Logger.Printf("[INSIDE] Close() error closing what was constructed after closing: %q", err)
+	}
+}
+
+
+// isClosed returns whether this container, or any of its ancestors, has been closed.
+func (container *internalContainer) isClosed() bool {
+	for c := container; nil != c; c = c.parent {
+		if c.closed.Load() {
+			return true
+		}
+	}
+
+	return false
+}
+
+
+// destroy calls the BeforeDestroy method (see BeforeDestroyer) and then the Close method (see
+// io.Closer) of the registration's dependency, if it has been provided, and it has them.
+func destroy(reg *registration) []error {
+	reg.mutex.Lock()
+	dependency, provided := reg.dependency, reg.provided
+	reg.mutex.Unlock()
+
+	if !provided {
+		return nil
+	}
+
+	var errs []error
+
+	if destroyer, ok := dependency.(BeforeDestroyer); ok {
+		if err := destroyer.BeforeDestroy(); nil != err {
+			errs = append(errs, err)
+		}
+	}
+
+	if closer, ok := dependency.(io.Closer); ok {
+		if err := closer.Close(); nil != err {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+
+// scopedInstances holds a scope's own copies of the scoped registrations (see the
+// scopedRegistration method).
+//
+// It also holds what the container constructed (see the constructed method), in the order
+// it was constructed. (Which is what the Close method closes.)
+type scopedInstances struct {
+	registrations map[*registration]*registration
+	order []*registration
+}
+
+
+func newScopedInstances() *scopedInstances {
+	instances := scopedInstances{
+		registrations:make(map[*registration]*registration),
+	}
+
+	return &instances
+}
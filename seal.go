@@ -0,0 +1,260 @@
+package container
+
+
+import (
+	"fmt"
+	"runtime"
+)
+
+
+// sealedRegistry is an immutable snapshot of a container's registry, aliases and groups,
+// taken when the container is sealed (see the Seal method).
+//
+// Since nothing can be registered with a sealed container, the snapshot never goes stale.
+// So, once a container is sealed, it is read from the snapshot without taking any locks.
+//
+// The values are the dependencies that had already been provided (and decorated) when the
+// container was sealed, keyed by name (and by the names of their aliases). These can be handed
+// out as is. (Dependencies that were not provided yet, and scoped dependencies, still need to
+// go through their registration.)
+type sealedRegistry struct {
+	registry map[string]*registration
+	aliases map[string]string
+	groups map[string][]groupMember
+	values map[string]interface{}
+
+	// decorated is whether the (scope) container has its own decorated copies of registrations
+	// inherited from its ancestors (see the Decorate method). If it does, then the values of its
+	// ancestors cannot be handed out as is.
+	decorated bool
+}
+
+
+// Seal seals the container, so that nothing more can be registered with it.
+//
+// This is meant to be called once main has finished bootstrapping, as in:
+//
+//	Container := container.New()
+//
+//	// Register everything here.
+//
+//	Container.Seal()
+//
+// After the container is sealed, Register, RegisterProvider, RegisterScoped, RegisterTransient,
+// Replace, Decorate, Alias, RegisterInGroup and RegisterNamedInGroup all return a SealedComplainer.
+//
+// Once sealed, Get, Inject (and so on) take no locks at all for dependencies that have already
+// been constructed (which includes everything registered with Register). Dependencies registered
+// with RegisterProvider are still constructed lazily, and take a lock for that.
+// (Because of this, once a container is sealed, Describe no longer finds out about new dependents.)
+//
+// Scopes made from a sealed container (see NewScope) are not themselves sealed, so
+// things can still be registered with them. Getting (or injecting) an already constructed
+// dependency of a sealed container through such a scope only takes the scope's own lock
+// (to see whether the scope has something of its own with that name), and no locks of the
+// sealed container. And sealing a namespace (see Namespace) seals the whole container.
+//
+// Calling Seal more than once does nothing.
+func (container *internalContainer) Seal() {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] Seal()")
+
+	container.mutex.Lock()
+	defer container.mutex.Unlock()
+
+	if nil != container.sealed.Load() {
+		logger.Printf("[END]   Seal()")
+		return
+	}
+
+	snapshot := sealedRegistry{
+		registry:make(map[string]*registration, len(container.registry)),
+		aliases:make(map[string]string, len(container.aliases)),
+		groups:make(map[string][]groupMember, len(container.groups)),
+		values:make(map[string]interface{}),
+		decorated:0 < len(container.decorated),
+	}
+
+	for name, reg := range container.registry {
+		snapshot.registry[name] = reg
+
+		reg.mutex.Lock()
+		reg.sealed = true
+		if reg.provided && !reg.scoped {
+			snapshot.values[name] = reg.dependency
+		}
+		reg.mutex.Unlock()
+	}
+
+	for alias, target := range container.aliases {
+		snapshot.aliases[alias] = target
+
+		// An alias can only be for something already registered (in this container, or
+		// in one of its ancestors), and can never end up being for itself. So this loop ends.
+		for {
+			next, isAlias := container.aliases[target]
+			if !isAlias {
+				break
+			}
+			target = next
+		}
+
+		if value, ok := snapshot.values[target]; ok {
+			snapshot.values[alias] = value
+		}
+	}
+
+	for groupName, members := range container.groups {
+		snapshot.groups[groupName] = append([]groupMember(nil), members...)
+	}
+
+	container.sealed.Store(&snapshot)
+
+	logger.Printf("[END]   Seal()")
+}
+
+
+// snapshot returns the sealedRegistry of the container, or nil if the container is not sealed.
+func (container *internalContainer) snapshot() *sealedRegistry {
+	return container.sealed.Load()
+}
+
+
+// sealedValue returns the already provided dependency with the given name, from the snapshot
+// of the sealed container it is registered with, without taking any of that container's locks.
+//
+// This container (and, if it is a scope, each of its ancestors below where the dependency is
+// registered) does not need to be sealed. For each one that isn't, its own lock is taken, just
+// to see that it has nothing of its own with that name. (Since scopes are usually not sealed,
+// this is what keeps getting, or injecting, through a per-request scope from taking the locks
+// of the sealed container the scope was made from.)
+//
+// If it can't be done, then ok is false, and the dependency should be resolved the usual way.
+// (If neither this container nor any of its ancestors is sealed, then that is known without
+// taking any locks.)
+func (container *internalContainer) sealedValue(dependencyName string) (dependency interface{}, ok bool) {
+	if !container.anySealed() {
+		return nil, false
+	}
+
+	for c := container; nil != c; c = c.parent {
+		snapshot := c.snapshot()
+
+		if nil == snapshot {
+			c.mutex.RLock()
+			_, registered := c.registry[dependencyName]
+			_, isAlias    := c.aliases[dependencyName]
+			decorated     := 0 < len(c.decorated)
+			c.mutex.RUnlock()
+
+			if registered || isAlias || decorated {
+				return nil, false
+			}
+			continue
+		}
+
+		if dependency, ok := snapshot.values[dependencyName]; ok {
+			return dependency, true
+		}
+
+		if _, ok := snapshot.registry[dependencyName]; ok {
+			return nil, false
+		}
+		if _, ok := snapshot.aliases[dependencyName]; ok {
+			return nil, false
+		}
+		if snapshot.decorated {
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+
+// anySealed returns whether this container, or any of its ancestors, is sealed.
+func (container *internalContainer) anySealed() bool {
+	for c := container; nil != c; c = c.parent {
+		if nil != c.snapshot() {
+			return true
+		}
+	}
+
+	return false
+}
+
+
+// Replace replaces the dependency registered with the given name.
+//
+// This is mostly useful for tests, where a real dependency gets replaced with a fake one.
+// For example:
+//
+//	err := Container.Replace("clock", fakeClock)
+//
+// Anything already injected with (or gotten from) the old dependency keeps it.
+// And any decorators (see Decorate) of the old dependency are not applied to the new one.
+//
+// If the name is an alias (see Alias), then what it is an alias for gets replaced.
+//
+// Only something registered with this container can be replaced. If nothing is registered
+// (with this container) with the name, then a DependenciesNotFoundComplainer is returned.
+// If the container is sealed, then a SealedComplainer is returned.
+func (container *internalContainer) Replace(dependencyName string, dependency interface{}) error {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] Replace(%q, <dependency> %T)", dependencyName, dependency)
+
+	if err := container.replace(dependencyName, newRegistration(dependency)); nil != err {
+		logger.Printf("[END]   Replace(%q, <dependency> %T) with ERROR: %q", dependencyName, dependency, err)
+		return err
+	}
+
+	logger.Printf("[END]   Replace(%q, <dependency> %T)", dependencyName, dependency)
+
+	return nil
+}
+
+// replace puts the registration into the registry in place of what is already registered
+// with that name.
+//
+// Like register, it makes a note of where the replacing was done from. And what the old
+// registration was required by carries over to the new one.
+func (container *internalContainer) replace(dependencyName string, reg *registration) error {
+	dependencyName = container.qualify(dependencyName)
+
+	if _, file, line, ok := runtime.Caller(2); ok {
+		reg.site = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	container.mutex.Lock()
+	defer container.mutex.Unlock()
+
+	if nil != container.sealed.Load() {
+		return newSealedComplainer(dependencyName)
+	}
+
+	name := dependencyName
+	for {
+		target, isAlias := container.aliases[name]
+		if !isAlias {
+			break
+		}
+		name = target
+	}
+
+	old, ok := container.registry[name]
+	if !ok {
+		return newDependenciesNotFoundComplainer(dependencyName)
+	}
+
+	for _, dependent := range old.dependentNames() {
+		reg.addDependent(dependent)
+	}
+
+	container.registry[name] = reg
+
+	return nil
+}
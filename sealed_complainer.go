@@ -0,0 +1,65 @@
+package container
+
+
+import (
+	"fmt"
+)
+
+
+// SealedComplainer is an 'error' that represents the situation where something tries to
+// register (or replace, decorate, alias, and so on) a dependency with a 'dependency injection container'
+// that has been sealed (see the Seal method).
+//
+// errors.Is(err, ErrSealed) reports true for a SealedComplainer.
+type SealedComplainer interface {
+	error
+	SealedComplainer()
+	DependencyName() string
+}
+
+
+// internalSealedComplainer is the only underlying implementation that fits the
+// SealedComplainer interface, in this library.
+type internalSealedComplainer struct {
+	dependencyName string
+}
+
+
+// newSealedComplainer creates a new internalSealedComplainer (struct) and
+// returns it as a SealedComplainer (interface).
+func newSealedComplainer(dependencyName string) SealedComplainer {
+	complainer := internalSealedComplainer{
+		dependencyName:dependencyName,
+	}
+
+	return &complainer
+}
+
+
+func (complainer *internalSealedComplainer) Error() string {
+	if "" == complainer.dependencyName {
+		return "Nothing can be registered or changed, because the container is sealed."
+	}
+
+	return fmt.Sprintf("Dependency %q cannot be registered or changed, because the container is sealed.", complainer.dependencyName)
+}
+
+
+func (complainer *internalSealedComplainer) SealedComplainer() {
+	// Nothing here.
+}
+
+
+// DependencyName method is necessary to satisfy the 'SealedComplainer' interface.
+//
+// It returns "" if what could not be done was not about any one dependency (as in,
+// restoring a snapshot of the whole container).
+func (complainer *internalSealedComplainer) DependencyName() string {
+	return complainer.dependencyName
+}
+
+
+// Is makes it so errors.Is(err, ErrSealed) works.
+func (complainer *internalSealedComplainer) Is(target error) bool {
+	return ErrSealed == target
+}
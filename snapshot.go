@@ -0,0 +1,221 @@
+package container
+
+
+import (
+	"errors"
+	"sync"
+)
+
+
+// Snapshot is what is registered with a container at some point in time. It is returned
+// by the Snapshot method, and can be passed to the Restore method.
+//
+// This is mostly useful for tests. (Also see the containertest package.)
+type Snapshot struct {
+	mutex *sync.RWMutex
+	names []string
+	registry map[string]*registration
+	aliases map[string]string
+	groups map[string][]groupMember
+	registrations map[*registration]registrationState
+}
+
+
+// registrationState is the part of a registration that can change after it is registered.
+type registrationState struct {
+	dependency interface{}
+	provided bool
+	decorators []func(interface{}) (interface{}, error)
+}
+
+
+// Snapshot returns what is currently registered with the container, so that it can be
+// put back later with the Restore method. For example:
+//
+//	snapshot := Container.Snapshot()
+//	defer Container.Restore(snapshot)
+//
+// If no names are given, then the snapshot is of the whole container. (If called on a
+// namespace (see Namespace) then it is of the whole container the namespace is part of.
+// If called on a scope (see NewScope) then it is of just what is registered with the scope.)
+//
+// If names are given, then the snapshot is of just what is registered with those names.
+// (If a name is an alias (see Alias), then it is of what it is an alias for.) Restoring it
+// puts back just those registrations, as they are then, and leaves everything else as is.
+// For example:
+//
+//	snapshot := Container.Snapshot("clock")
+//	defer Container.Restore(snapshot)
+//
+//	err := Container.Replace("clock", fakeClock)
+func (container *internalContainer) Snapshot(names ...string) Snapshot {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] Snapshot(%q)", names)
+
+	container.mutex.RLock()
+	defer container.mutex.RUnlock()
+
+	if 0 < len(names) {
+		snapshot := Snapshot{
+			mutex:container.mutex,
+			names:make([]string, 0, len(names)),
+			registry:make(map[string]*registration, len(names)),
+		}
+
+		for _, name := range names {
+			name = container.qualify(name)
+			for {
+				target, isAlias := container.aliases[name]
+				if !isAlias {
+					break
+				}
+				name = target
+			}
+
+			snapshot.names = append(snapshot.names, name)
+			if reg, ok := container.registry[name]; ok {
+				snapshot.registry[name] = reg
+			}
+		}
+
+		logger.Printf("[END]   Snapshot(%q)", names)
+
+		return snapshot
+	}
+
+	snapshot := Snapshot{
+		mutex:container.mutex,
+		registry:make(map[string]*registration, len(container.registry)),
+		aliases:make(map[string]string, len(container.aliases)),
+		groups:make(map[string][]groupMember, len(container.groups)),
+		registrations:make(map[*registration]registrationState, len(container.registry)),
+	}
+
+	for name, reg := range container.registry {
+		snapshot.registry[name] = reg
+
+		reg.mutex.Lock()
+		snapshot.registrations[reg] = registrationState{
+			dependency:reg.dependency,
+			provided:reg.provided,
+			decorators:append([]func(interface{}) (interface{}, error)(nil), reg.decorators...),
+		}
+		reg.mutex.Unlock()
+	}
+
+	for alias, target := range container.aliases {
+		snapshot.aliases[alias] = target
+	}
+
+	for groupName, members := range container.groups {
+		snapshot.groups[groupName] = append([]groupMember(nil), members...)
+	}
+
+	logger.Printf("[END]   Snapshot(%q)", names)
+
+	return snapshot
+}
+
+
+// Restore puts back what was registered with the container when the Snapshot method
+// returned the snapshot.
+//
+// Anything registered since then is removed. Anything replaced (see Replace) since then
+// is put back. And any decorators (see Decorate) added since then are removed. But any
+// dependency already injected (or gotten) since then is left as is.
+//
+// A dependency registered with RegisterProvider that was constructed since then is kept
+// as it is (rather than being constructed again the next time it is needed). So there is
+// still just one instance of it, which is what Close closes.
+//
+// (If the snapshot is of just some names, then only what is registered with those names
+// is put back. See the Snapshot method.)
+//
+// If the snapshot is not of this container, then an error is returned. If the container
+// is sealed (see Seal), then a SealedComplainer is returned.
+func (container *internalContainer) Restore(snapshot Snapshot) error {
+
+	logger := container.dependencies.Logger
+
+	logger.Printf("[BEGIN] Restore(<snapshot>)")
+
+	if err := container.restore(snapshot); nil != err {
+		logger.Printf("[END]   Restore(<snapshot>) with ERROR: %q", err)
+		return err
+	}
+
+	logger.Printf("[END]   Restore(<snapshot>)")
+
+	return nil
+}
+
+func (container *internalContainer) restore(snapshot Snapshot) error {
+	if container.mutex != snapshot.mutex {
+		return errors.New("Cannot restore a snapshot of a different container.")
+	}
+
+	container.mutex.Lock()
+	defer container.mutex.Unlock()
+
+	if nil != container.sealed.Load() {
+		// A snapshot of the whole container is not about any one dependency.
+		var dependencyName string
+		if 0 < len(snapshot.names) {
+			dependencyName = snapshot.names[0]
+		}
+
+		return newSealedComplainer(dependencyName)
+	}
+
+	if nil != snapshot.names {
+		for _, name := range snapshot.names {
+			reg, ok := snapshot.registry[name]
+			if !ok {
+				delete(container.registry, name)
+				continue
+			}
+
+			container.registry[name] = reg
+		}
+
+		return nil
+	}
+
+	// The maps are changed in place (rather than replaced) since namespaces (see Namespace)
+	// share them.
+	for name := range container.registry {
+		delete(container.registry, name)
+	}
+	for name, reg := range snapshot.registry {
+		container.registry[name] = reg
+
+		state := snapshot.registrations[reg]
+
+		reg.mutex.Lock()
+		// A dependency constructed since then is kept (rather than constructed again, when it is
+		// next needed), since it is what the container will close. (See the Close method.)
+		if state.provided {
+			reg.setDependency(state.dependency, state.provided)
+		}
+		reg.decorators = append([]func(interface{}) (interface{}, error)(nil), state.decorators...)
+		reg.mutex.Unlock()
+	}
+
+	for name := range container.aliases {
+		delete(container.aliases, name)
+	}
+	for alias, target := range snapshot.aliases {
+		container.aliases[alias] = target
+	}
+
+	for name := range container.groups {
+		delete(container.groups, name)
+	}
+	for groupName, members := range snapshot.groups {
+		container.groups[groupName] = append([]groupMember(nil), members...)
+	}
+
+	return nil
+}
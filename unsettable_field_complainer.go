@@ -0,0 +1,107 @@
+package container
+
+
+import (
+	"fmt"
+)
+
+
+// UnsettableFieldComplainer is an 'error' that represents the situation where
+// a struct field has an `inject` struct tag, but the 'dependency injection container'
+// would not be able to set that struct field.
+//
+// This happens when the struct field is unexported (i.e., its name begins with a
+// lowercase letter), or when the struct itself is not addressable (for example,
+// because the struct itself, and not a pointer to it, was passed to Inject).
+//
+// The Depender interface is the way to keep the dependencies of a struct hidden,
+// while still being able to have them injected.
+//
+// UnsettableFieldComplainers are returned by the Check func, and by the Inject
+// method of a container created with the Strict option.
+//
+// errors.Is(err, ErrUnsettableField) reports true for an UnsettableFieldComplainer.
+type UnsettableFieldComplainer interface {
+	error
+	UnsettableFieldComplainer()
+	DependencyName() string
+	Field() string
+	Path() string
+}
+
+
+// internalUnsettableFieldComplainer is the only underlying implementation that fits the
+// UnsettableFieldComplainer interface, in this library.
+type internalUnsettableFieldComplainer struct {
+	dependencyName string
+	field string
+	path string
+	unexported bool
+}
+
+
+// newUnsettableFieldComplainer creates a new internalUnsettableFieldComplainer (struct) and
+// returns it as an UnsettableFieldComplainer (interface).
+//
+// The field is the struct type and field name, as in:
+//
+//	*billing.Service.db
+//
+// If unexported is false, then the complaint is about the struct not being addressable.
+func newUnsettableFieldComplainer(dependencyName string, field string, path string, unexported bool) UnsettableFieldComplainer {
+	complainer := internalUnsettableFieldComplainer{
+		dependencyName:dependencyName,
+		field:field,
+		path:path,
+		unexported:unexported,
+	}
+
+	return &complainer
+}
+
+
+func (complainer *internalUnsettableFieldComplainer) Error() string {
+	var at string
+	if "" != complainer.path {
+		at = " at " + complainer.path
+	}
+
+	if complainer.unexported {
+		return fmt.Sprintf("Cannot inject dependency %q into %s%s because the field is unexported. Either export the field, or move it into a struct of its own (with exported fields) and return a pointer to that struct from a Dependencies method (see Depender).", complainer.dependencyName, complainer.field, at)
+	}
+
+	return fmt.Sprintf("Cannot inject dependency %q into %s%s because the struct is not addressable. Pass a pointer to the struct instead (and, if it is returned from a Dependencies method, return a pointer to it).", complainer.dependencyName, complainer.field, at)
+}
+
+
+func (complainer *internalUnsettableFieldComplainer) UnsettableFieldComplainer() {
+	// Nothing here.
+}
+
+
+// DependencyName method is necessary to satisfy the 'UnsettableFieldComplainer' interface.
+func (complainer *internalUnsettableFieldComplainer) DependencyName() string {
+	return complainer.dependencyName
+}
+
+
+// Field method is necessary to satisfy the 'UnsettableFieldComplainer' interface.
+//
+// It returns the struct type and field name, as in:
+//
+//	*billing.Service.db
+func (complainer *internalUnsettableFieldComplainer) Field() string {
+	return complainer.field
+}
+
+
+// Path method is necessary to satisfy the 'UnsettableFieldComplainer' interface.
+func (complainer *internalUnsettableFieldComplainer) Path() string {
+	return complainer.path
+}
+
+
+// Is makes it so errors.Is(err, ErrUnsettableField) works.
+func (complainer *internalUnsettableFieldComplainer) Is(target error) bool {
+	return ErrUnsettableField == target
+}
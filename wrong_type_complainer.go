@@ -11,22 +11,58 @@ import (
 const wrongTypeMessagePrefix = "Wrong type for dependency "
 
 
+// WrongTypeComplainer is an 'error' that represents the situation where the
+// 'dependency injection container' tries to inject a dependency into a struct
+// field, but the type of the registered dependency is not assignable to the
+// type of the struct field.
+//
+// The Path method says where the struct field is, relative to what was passed
+// to the Inject method. For example:
+//
+//	["eu-west"][3].Dependencies().Store
+//
+// A WrongTypeComplainer can also be about a parameter of a func passed to the Invoke
+// method (or the Construct func), rather than a struct field. Then Path returns ""
+// (empty string), and the error message says which parameter it is about.
+//
+// errors.Is(err, ErrWrongType) reports true for a WrongTypeComplainer.
 type WrongTypeComplainer interface {
 	Error() string
 	DependencyName() string
+	Path() string
 }
 
 // internalWrongTypeComplainer is the only underlying implementation that fits the
 // WrongTypeComplainer interface, in this library.
 type internalWrongTypeComplainer struct {
 	dependencyName string
+	path string
+
+	// requiredBy is the func and parameter the dependency could not be passed as, as in:
+	//
+	//	billing.NewService parameter #2
+	//
+	// It is "" (empty string) when the complainer is about a struct field.
+	requiredBy string
 }
 
 // newWrongTypeComplainer creates a new internalWrongTypeComplainer (struct) and
 // returns it as a WrongTypeComplainer (interface).
-func newWrongTypeComplainer(dependencyName string) WrongTypeComplainer {
+func newWrongTypeComplainer(dependencyName string, path string) WrongTypeComplainer {
+	err := internalWrongTypeComplainer{
+		dependencyName:dependencyName,
+		path:path,
+	}
+
+	return &err
+}
+
+// newParameterWrongTypeComplainer creates a new internalWrongTypeComplainer (struct), for a
+// func parameter (rather than a struct field), and returns it as a WrongTypeComplainer (interface).
+func newParameterWrongTypeComplainer(dependencyName string, requiredBy string) WrongTypeComplainer {
 	err := internalWrongTypeComplainer{
 		dependencyName:dependencyName,
+		requiredBy:requiredBy,
 	}
 
 	return &err
@@ -40,6 +76,14 @@ func (err *internalWrongTypeComplainer) Error() string {
 
 	io.WriteString(&buffer, wrongTypeMessagePrefix)
 	io.WriteString(&buffer, fmt.Sprintf("%q", err.dependencyName))
+	if "" != err.path {
+		io.WriteString(&buffer, " at ")
+		io.WriteString(&buffer, err.path)
+	}
+	if "" != err.requiredBy {
+		io.WriteString(&buffer, " required by ")
+		io.WriteString(&buffer, err.requiredBy)
+	}
 
 	return buffer.String()
 }
@@ -48,3 +92,15 @@ func (err *internalWrongTypeComplainer) Error() string {
 func (err *internalWrongTypeComplainer) DependencyName() string {
 	return err.dependencyName
 }
+
+
+// Path method is necessary to satisfy the 'WrongTypeComplainer' interface.
+func (err *internalWrongTypeComplainer) Path() string {
+	return err.path
+}
+
+
+// Is makes it so errors.Is(err, ErrWrongType) works.
+func (err *internalWrongTypeComplainer) Is(target error) bool {
+	return ErrWrongType == target
+}